@@ -5,7 +5,6 @@ go 1.23
 require (
 	github.com/FollowTheProcess/collections v0.10.0
 	github.com/FollowTheProcess/msg v1.2.0
-	github.com/FollowTheProcess/parser v0.3.0
 	github.com/FollowTheProcess/test v0.17.1
 )
 
@@ -0,0 +1,229 @@
+// Package aoc defines the types shared by every day's solution, so that runners,
+// benchmarks and verifiers can treat each day the same way.
+package aoc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Year is the Advent of Code event these solutions are for.
+const Year = 2024
+
+// ErrUnsolved is returned by a part that hasn't been solved yet, like the ones in a
+// freshly scaffolded day. It counts as a missing answer rather than a failure.
+var ErrUnsolved = errors.New("not solved yet")
+
+// Answer is the answer to one part of a puzzle.
+//
+// Most answers are numbers but some puzzles want text, so answers are kept as
+// the string that would be typed into the website.
+type Answer string
+
+// Int returns the Answer for an integer result.
+func Int(n int) Answer {
+	return Answer(strconv.Itoa(n))
+}
+
+// String implements [fmt.Stringer] for an Answer.
+func (a Answer) String() string {
+	return string(a)
+}
+
+// Solution is implemented by every day's puzzle solution.
+//
+// Parse is always called exactly once, before either part is solved, and
+// each part is free to rely on the state Parse has built up.
+type Solution interface {
+	// Parse parses the raw puzzle input.
+	Parse(input string) error
+
+	// Part1 solves part 1 of the puzzle using the parsed input.
+	Part1() (Answer, error)
+
+	// Part2 solves part 2 of the puzzle using the parsed input.
+	Part2() (Answer, error)
+}
+
+// Explanation is a step by step account of how a solution reached its answers.
+//
+// Its String method gives the human readable form, and it must marshal cleanly
+// to JSON for tools.
+type Explanation interface {
+	fmt.Stringer
+}
+
+// Explainer is implemented by solutions that can show their working, like the
+// walkthrough in the puzzle text, to help debug a wrong answer.
+//
+// Like the parts, Explain is only called after Parse.
+type Explainer interface {
+	Explain() (Explanation, error)
+}
+
+// Streamer is implemented by solutions that can solve both parts in a single pass
+// over a reader, for inputs too big to hold in memory as one string.
+//
+// Stream is called on a fresh Solution instead of Parse and the parts, after any
+// options have been set.
+type Streamer interface {
+	Stream(r io.Reader) ([2]Answer, error)
+}
+
+// Configurable is implemented by solutions with options that change how they
+// solve the puzzle, e.g. looser rules for what counts as valid.
+//
+// Options are set on a fresh Solution, before Parse.
+type Configurable interface {
+	// Set sets the named option from its string form, returning an error if the
+	// option doesn't exist or value isn't valid for it.
+	Set(name, value string) error
+}
+
+// Option is a named option for a [Configurable] solution.
+type Option struct {
+	Name  string // The option name e.g. "max-step"
+	Value string // The value in string form e.g. "4"
+}
+
+// ParseOption parses an option of the form "name=value".
+func ParseOption(raw string) (Option, error) {
+	name, value, ok := strings.Cut(raw, "=")
+	if !ok || name == "" {
+		return Option{}, fmt.Errorf("bad option %q, expected name=value", raw)
+	}
+
+	return Option{Name: name, Value: value}, nil
+}
+
+// Day is a single day of Advent of Code, ready to be registered with the runner.
+type Day struct {
+	New     func() Solution               // Returns a fresh, unparsed Solution
+	Open    func() (io.ReadCloser, error) // If set, opens the input to stream instead of using Input, see [Day.WithStream]
+	Input   string                        // The embedded puzzle input
+	Answers string                        // The embedded known answers for Input, see [ParseAnswers]
+	Number  int                           // The day number e.g. 1 for December 1st
+}
+
+// WithInput returns a copy of the day that is solved using the puzzle input read
+// from r, rather than its embedded input.
+//
+// The known answers belong to the embedded input so they are dropped.
+func (d Day) WithInput(r io.Reader) (Day, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return Day{}, fmt.Errorf("could not read input for day %d: %w", d.Number, err)
+	}
+
+	d.Input = string(raw)
+	d.Answers = ""
+
+	return d, nil
+}
+
+// WithStream returns a copy of the day that is solved using the puzzle input from
+// open, rather than its embedded input.
+//
+// If the day's solution is a [Streamer] the input is streamed through it when the
+// day is solved, so it never has to fit in memory. Otherwise it's read in full
+// straight away, just like WithInput. Either way the known answers are dropped.
+func (d Day) WithStream(open func() (io.ReadCloser, error)) (Day, error) {
+	if _, ok := d.New().(Streamer); ok {
+		d.Open = open
+		d.Input = ""
+		d.Answers = ""
+
+		return d, nil
+	}
+
+	r, err := open()
+	if err != nil {
+		return Day{}, fmt.Errorf("could not open input for day %d: %w", d.Number, err)
+	}
+	defer r.Close()
+
+	return d.WithInput(r)
+}
+
+// WithOptions returns a copy of the day whose solutions have the given options set,
+// it is an error if the day's solution isn't [Configurable] or rejects any of them.
+//
+// The known answers are for the default options so they are dropped.
+func (d Day) WithOptions(options ...Option) (Day, error) {
+	// Try them out on a throwaway solution so New never has to fail
+	if err := setOptions(d.New(), options); err != nil {
+		return Day{}, fmt.Errorf("day %d: %w", d.Number, err)
+	}
+
+	newSolution := d.New
+	d.New = func() Solution {
+		solution := newSolution()
+		setOptions(solution, options) //nolint:errcheck // Already checked above
+
+		return solution
+	}
+	d.Answers = ""
+
+	return d, nil
+}
+
+// setOptions sets each of options on solution.
+func setOptions(solution Solution, options []Option) error {
+	configurable, ok := solution.(Configurable)
+	if !ok {
+		return errors.New("solution has no options")
+	}
+
+	for _, option := range options {
+		if err := configurable.Set(option.Name, option.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseAnswers parses a day's known answers file.
+//
+// The file has one line per known answer of the form "part1: <answer>" or
+// "part2: <answer>", blank lines are ignored. A part with no line has no known
+// answer yet and is returned as the empty Answer.
+func ParseAnswers(raw string) ([2]Answer, error) {
+	var answers [2]Answer
+
+	scanner := bufio.NewScanner(strings.NewReader(raw))
+	lineNo := 0
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return [2]Answer{}, fmt.Errorf("bad answer on line %d: expected 'part<n>: <answer>', got %q", lineNo, line)
+		}
+
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return [2]Answer{}, fmt.Errorf("missing answer on line %d: %q", lineNo, line)
+		}
+
+		switch strings.TrimSpace(key) {
+		case "part1":
+			answers[0] = Answer(value)
+		case "part2":
+			answers[1] = Answer(value)
+		default:
+			return [2]Answer{}, fmt.Errorf("bad answer on line %d: unknown part %q", lineNo, key)
+		}
+	}
+
+	return answers, scanner.Err()
+}
@@ -0,0 +1,200 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/FollowTheProcess/test"
+)
+
+func TestInt(t *testing.T) {
+	test.Equal(t, Int(0), Answer("0"))
+	test.Equal(t, Int(-42), Answer("-42"))
+	test.Equal(t, Int(175700056).String(), "175700056")
+}
+
+func TestWithInput(t *testing.T) {
+	day := Day{Number: 1, Input: "embedded", Answers: "part1: 1"}
+
+	overridden, err := day.WithInput(strings.NewReader("from a reader"))
+	test.Ok(t, err)
+
+	test.Equal(t, overridden.Input, "from a reader")
+	test.Equal(t, overridden.Number, 1)
+	test.Equal(t, overridden.Answers, "") // Known answers don't apply to other inputs
+	test.Equal(t, day.Input, "embedded")  // Original day should be untouched
+
+	_, err = day.WithInput(iotest.ErrReader(iotest.ErrTimeout))
+	test.Err(t, err)
+}
+
+// streamer is a Solution that can also stream, answering both parts with its input.
+type streamer struct{ options }
+
+func (s *streamer) Stream(r io.Reader) ([2]Answer, error) {
+	raw, err := io.ReadAll(r)
+	return [2]Answer{Answer(raw), Answer(raw)}, err
+}
+
+func TestWithStream(t *testing.T) {
+	opened := 0
+	open := func() (io.ReadCloser, error) {
+		opened++
+		return io.NopCloser(strings.NewReader("from a stream")), nil
+	}
+
+	t.Run("streamer", func(t *testing.T) {
+		opened = 0
+		day := Day{Number: 1, Input: "embedded", Answers: "part1: 1", New: func() Solution { return &streamer{} }}
+
+		streamed, err := day.WithStream(open)
+		test.Ok(t, err)
+		test.Equal(t, opened, 0) // Not opened until the day is solved
+		test.Equal(t, streamed.Input, "")
+		test.Equal(t, streamed.Answers, "")
+		test.True(t, streamed.Open != nil)
+	})
+
+	t.Run("not a streamer", func(t *testing.T) {
+		opened = 0
+		day := Day{Number: 1, Input: "embedded", Answers: "part1: 1", New: func() Solution { return &options{} }}
+
+		read, err := day.WithStream(open)
+		test.Ok(t, err)
+		test.Equal(t, opened, 1) // Read in full straight away
+		test.Equal(t, read.Input, "from a stream")
+		test.Equal(t, read.Answers, "")
+		test.True(t, read.Open == nil)
+	})
+
+	t.Run("open fails", func(t *testing.T) {
+		day := Day{Number: 1, New: func() Solution { return &options{} }}
+		_, err := day.WithStream(func() (io.ReadCloser, error) { return nil, iotest.ErrTimeout })
+		test.Err(t, err)
+	})
+}
+
+// options is a Solution with a single option, "answer", reported as its part 1 answer.
+type options struct {
+	answer string
+}
+
+func (o *options) Parse(string) error     { return nil }
+func (o *options) Part1() (Answer, error) { return Answer(o.answer), nil }
+func (o *options) Part2() (Answer, error) { return "", nil }
+
+func (o *options) Set(name, value string) error {
+	if name != "answer" {
+		return fmt.Errorf("unknown option %q", name)
+	}
+
+	o.answer = value
+
+	return nil
+}
+
+// noOptions is a Solution that isn't Configurable.
+type noOptions struct{}
+
+func (n *noOptions) Parse(string) error     { return nil }
+func (n *noOptions) Part1() (Answer, error) { return "", nil }
+func (n *noOptions) Part2() (Answer, error) { return "", nil }
+
+func TestParseOption(t *testing.T) {
+	option, err := ParseOption("max-step=4")
+	test.Ok(t, err)
+	test.Equal(t, option, Option{Name: "max-step", Value: "4"})
+
+	option, err = ParseOption("empty=")
+	test.Ok(t, err)
+	test.Equal(t, option, Option{Name: "empty"})
+
+	_, err = ParseOption("max-step")
+	test.Err(t, err)
+
+	_, err = ParseOption("=4")
+	test.Err(t, err)
+}
+
+func TestWithOptions(t *testing.T) {
+	day := Day{Number: 1, Answers: "part1: 1", New: func() Solution { return &options{answer: "default"} }}
+
+	configured, err := day.WithOptions(Option{Name: "answer", Value: "42"})
+	test.Ok(t, err)
+	test.Equal(t, configured.Answers, "") // Known answers are for the default options
+
+	// Every new solution gets the options
+	for range 2 {
+		answer, err := configured.New().Part1()
+		test.Ok(t, err)
+		test.Equal(t, answer, Answer("42"))
+	}
+
+	// Original day should be untouched
+	answer, err := day.New().Part1()
+	test.Ok(t, err)
+	test.Equal(t, answer, Answer("default"))
+
+	_, err = day.WithOptions(Option{Name: "colour", Value: "red"})
+	test.Err(t, err)
+
+	day.New = func() Solution { return &noOptions{} }
+	_, err = day.WithOptions(Option{Name: "answer", Value: "42"})
+	test.Err(t, err)
+}
+
+func TestParseAnswers(t *testing.T) {
+	tests := []struct {
+		name    string    // Name of the test case
+		raw     string    // The raw answers file
+		want    [2]Answer // The expected answers
+		wantErr bool      // Whether we want a parse error
+	}{
+		{
+			name: "empty",
+			raw:  "",
+			want: [2]Answer{},
+		},
+		{
+			name: "both parts",
+			raw:  "part1: 11\npart2: 31\n",
+			want: [2]Answer{"11", "31"},
+		},
+		{
+			name: "part 1 only",
+			raw:  "part1: 11\n",
+			want: [2]Answer{"11", ""},
+		},
+		{
+			name: "blank lines and spaces",
+			raw:  "\n  part2:   4,6,3,5  \n\n",
+			want: [2]Answer{"", "4,6,3,5"},
+		},
+		{
+			name:    "no colon",
+			raw:     "part1 11\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown part",
+			raw:     "part3: 11\n",
+			wantErr: true,
+		},
+		{
+			name:    "no answer",
+			raw:     "part1:\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAnswers(tt.raw)
+			test.WantErr(t, err, tt.wantErr)
+			test.Equal(t, got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,651 @@
+// Package cli implements the aoc command line interface, dispatching subcommands
+// to the registered days.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/aoc2024/internal/client"
+	"github.com/FollowTheProcess/aoc2024/internal/day01"
+	"github.com/FollowTheProcess/aoc2024/internal/day02"
+	"github.com/FollowTheProcess/aoc2024/internal/days"
+	"github.com/FollowTheProcess/aoc2024/internal/runner"
+	"github.com/FollowTheProcess/aoc2024/internal/scaffold"
+	"github.com/FollowTheProcess/aoc2024/internal/verify"
+)
+
+const usage = `Advent of Code 2024, in Go!
+
+Usage:
+  aoc <command> [arguments]
+
+Commands:
+  run <day|all>      Solve a single day, or every registered day in parallel
+  verify [day|all]   Check answers against each day's known answers (default: all)
+  new <day>          Generate and register the package for a new day
+  download <day>     Download a day's puzzle input into its package
+  submit <day> <part> [answer]
+                     Submit an answer, solving the day to get it if not given
+  compare [path]     Compare every pair of columns of day 1 style location ID lists
+                     in path, or stdin if path is "-" or not given
+  monitor [path]     Check day 2 style reactor reports as they arrive in path, or
+                     stdin if path is "-" or not given, printing running totals
+  help               Show this help text
+
+Run Flags:
+  --workers <n>      Maximum number of days to solve at once (default: number of CPUs)
+  --input <path>     Solve a single day using the input in path, or stdin if path is "-"
+  --format <fmt>     Output format, one of text, json or ndjson (default: text)
+  --explain          Show a single day's working step by step, if the day supports it
+  --set <name=value> Set an option for a single day, may be repeated (e.g. day 2's
+                     min-step, max-step, removals, plateaus and direction)
+
+Verify Flags:
+  --workers <n>      Maximum number of days to verify at once (default: number of CPUs)
+
+New Flags:
+  --root <dir>       The repository root containing go.mod (default: ".")
+  --templates <dir>  Directory of templates overriding the built in day.go.tmpl,
+                     day_test.go.tmpl or registry.go.tmpl
+
+Download Flags:
+  --root <dir>       The repository root containing go.mod (default: ".")
+  --output <path>    Where to write the input, "-" for stdout (default: internal/dayNN/dayNN.txt)
+  --cache-dir <dir>  Where downloaded inputs are cached (default: <user cache dir>/aoc)
+
+Submit Flags:
+  --cache-dir <dir>  Where past guesses are recorded (default: <user cache dir>/aoc)
+
+Compare Flags:
+  --format <fmt>     Output format, one of text or csv (default: text)
+
+Monitor Flags:
+  --every <duration> Print running totals at most this often, 0 for only at the end
+                     (default: 1s)
+  --set <name=value> Set a day 2 option, may be repeated
+
+Downloading and submitting need your adventofcode.com session cookie, either in
+$AOC_SESSION or saved in <user config dir>/aoc/session.
+
+Examples:
+  aoc run 2
+  aoc run all
+  aoc run all --workers 1
+  aoc run 1 --input example.txt
+  cat example.txt | aoc run 1 --input -
+  aoc run all --format ndjson
+  aoc run 1 --explain --format json
+  aoc run 2 --set max-step=4 --set direction=increasing
+  aoc verify
+  aoc new 4
+  aoc download 4
+  aoc submit 4 1
+  aoc submit 4 2 1234
+  aoc compare lists.txt --format csv
+  tail -f reactor.log | aoc monitor --every 5s
+`
+
+// App is the aoc command line application.
+type App struct {
+	stdin    io.Reader     // Puzzle input when --input is "-"
+	stdout   io.Writer     // Normal program output
+	stderr   io.Writer     // Usage and diagnostics
+	baseURL  string        // The Advent of Code website, swapped for a fake in tests
+	interval time.Duration // Minimum time between requests to the website, 0 for the default
+}
+
+// New returns a new App reading from stdin and writing to stdout and stderr.
+func New(stdin io.Reader, stdout, stderr io.Writer) App {
+	return App{stdin: stdin, stdout: stdout, stderr: stderr, baseURL: client.DefaultBaseURL}
+}
+
+// Run runs the CLI with the given arguments, not including the program name.
+func (a App) Run(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(a.stderr, usage)
+		return errors.New("no command given")
+	}
+
+	command, rest := args[0], args[1:]
+	switch command {
+	case "run":
+		return a.run(rest)
+	case "verify":
+		return a.verify(rest)
+	case "new":
+		return a.new(rest)
+	case "download":
+		return a.download(rest)
+	case "submit":
+		return a.submit(rest)
+	case "compare":
+		return a.compare(rest)
+	case "monitor":
+		return a.monitor(rest)
+	case "help", "-h", "--help":
+		fmt.Fprint(a.stdout, usage)
+		return nil
+	default:
+		fmt.Fprint(a.stderr, usage)
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// run implements the run subcommand, solving one or all days and printing
+// the answers and timings in the requested format.
+func (a App) run(args []string) error {
+	flags := a.flagSet("run")
+	workers := flags.Int("workers", 0, "Maximum number of days to solve at once")
+	input := flags.String("input", "", "Read puzzle input from a file, or - for stdin")
+	formatName := flags.String("format", string(runner.FormatText), "Output format: text, json or ndjson")
+	explain := flags.Bool("explain", false, "Show a single day's working step by step")
+
+	options := optionsFlag(flags)
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	format, err := runner.ParseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return fmt.Errorf("run expects exactly 1 argument (a day number or 'all'), got %d", len(positional))
+	}
+
+	selected, err := selectDays(positional[0])
+	if err != nil {
+		return err
+	}
+
+	if *input != "" {
+		if len(selected) != 1 {
+			return errors.New("--input can only be used when running a single day")
+		}
+
+		// Explaining needs the whole input to hand, so only stream when solving
+		selected[0], err = a.withInput(selected[0], *input, !*explain)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(*options) != 0 {
+		if len(selected) != 1 {
+			return errors.New("--set can only be used when running a single day")
+		}
+
+		selected[0], err = selected[0].WithOptions(*options...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *explain {
+		if len(selected) != 1 {
+			return errors.New("--explain can only be used when running a single day")
+		}
+
+		return a.explain(selected[0], format)
+	}
+
+	results := runner.Run(selected, *workers)
+	if err := runner.Write(a.stdout, format, results); err != nil {
+		return err
+	}
+
+	return results.Err()
+}
+
+// explain parses the day's input and prints the solution's explanation of its
+// answers in the given format.
+func (a App) explain(day aoc.Day, format runner.Format) error {
+	solution := day.New()
+
+	explainer, ok := solution.(aoc.Explainer)
+	if !ok {
+		return fmt.Errorf("day %d can't explain its answers", day.Number)
+	}
+
+	if err := solution.Parse(day.Input); err != nil {
+		return fmt.Errorf("day %d: %w", day.Number, err)
+	}
+
+	explanation, err := explainer.Explain()
+	if err != nil {
+		return fmt.Errorf("day %d: %w", day.Number, err)
+	}
+
+	switch format {
+	case runner.FormatText:
+		_, err = fmt.Fprint(a.stdout, explanation)
+	case runner.FormatJSON:
+		encoder := json.NewEncoder(a.stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(explanation)
+	case runner.FormatNDJSON:
+		err = json.NewEncoder(a.stdout).Encode(explanation)
+	default:
+		err = fmt.Errorf("unknown format %q", format)
+	}
+
+	return err
+}
+
+// verify implements the verify subcommand, checking one or all days against
+// their known answers.
+func (a App) verify(args []string) error {
+	flags := a.flagSet("verify")
+	workers := flags.Int("workers", 0, "Maximum number of days to verify at once")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	arg := "all"
+	switch len(positional) {
+	case 0:
+		// Verify everything
+	case 1:
+		arg = positional[0]
+	default:
+		return fmt.Errorf("verify expects at most 1 argument (a day number or 'all'), got %d", len(positional))
+	}
+
+	selected, err := selectDays(arg)
+	if err != nil {
+		return err
+	}
+
+	report := verify.Verify(selected, *workers)
+	if err := verify.WriteReport(a.stdout, report); err != nil {
+		return err
+	}
+
+	return report.Err()
+}
+
+// new implements the new subcommand, scaffolding a new day.
+func (a App) new(args []string) error {
+	flags := a.flagSet("new")
+	root := flags.String("root", ".", "The repository root containing go.mod")
+	templates := flags.String("templates", "", "Directory of templates overriding the built in ones")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return fmt.Errorf("new expects exactly 1 argument (a day number), got %d", len(positional))
+	}
+
+	day, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return fmt.Errorf("bad day %q, expected a number", positional[0])
+	}
+
+	if err := scaffold.New(*root, *templates).Generate(day); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Created day %d in internal/day%02d\n", day, day)
+
+	return nil
+}
+
+// download implements the download subcommand, fetching a day's puzzle input.
+func (a App) download(args []string) error {
+	flags := a.flagSet("download")
+	root := flags.String("root", ".", "The repository root containing go.mod")
+	output := flags.String("output", "", "Where to write the input, - for stdout")
+	cacheDir := flags.String("cache-dir", "", "Where downloaded inputs are cached")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return fmt.Errorf("download expects exactly 1 argument (a day number), got %d", len(positional))
+	}
+
+	day, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return fmt.Errorf("bad day %q, expected a number", positional[0])
+	}
+
+	path := *output
+	if path == "" {
+		pkg := fmt.Sprintf("day%02d", day)
+		path = filepath.Join(*root, "internal", pkg, pkg+".txt")
+
+		if _, err := os.Stat(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("day %d has no package yet, create it with 'aoc new %d' first: %w", day, day, err)
+		}
+	}
+
+	// Don't bother downloading if we'd only refuse to write it
+	if path != "-" {
+		if info, err := os.Stat(path); err == nil && info.Size() != 0 {
+			return fmt.Errorf("%s already contains an input, refusing to overwrite it", path)
+		}
+	}
+
+	c, err := a.client(*cacheDir)
+	if err != nil {
+		return err
+	}
+
+	input, err := c.Input(context.Background(), day)
+	if err != nil {
+		return err
+	}
+
+	if path == "-" {
+		_, err := io.WriteString(a.stdout, input)
+		return err
+	}
+
+	if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Saved day %d input to %s\n", day, path)
+
+	return nil
+}
+
+// submit implements the submit subcommand, submitting an answer for one part
+// of a day.
+func (a App) submit(args []string) error {
+	flags := a.flagSet("submit")
+	cacheDir := flags.String("cache-dir", "", "Where past guesses are recorded")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 2 && len(positional) != 3 {
+		return fmt.Errorf("submit expects a day, a part and optionally an answer, got %d arguments", len(positional))
+	}
+
+	day, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return fmt.Errorf("bad day %q, expected a number", positional[0])
+	}
+
+	part, err := strconv.Atoi(positional[1])
+	if err != nil || (part != 1 && part != 2) {
+		return fmt.Errorf("bad part %q, expected 1 or 2", positional[1])
+	}
+
+	var answer aoc.Answer
+	if len(positional) == 3 {
+		answer = aoc.Answer(positional[2])
+	} else {
+		answer, err = solvePart(day, part)
+		if err != nil {
+			return err
+		}
+	}
+
+	c, err := a.client(*cacheDir)
+	if err != nil {
+		return err
+	}
+
+	submission, err := c.Submit(context.Background(), day, part, answer)
+	if err != nil {
+		return err
+	}
+
+	source := "adventofcode.com"
+	if submission.Cached {
+		source = "past guesses"
+	}
+
+	fmt.Fprintf(a.stdout, "Day %d part %d: %s is %s (from %s)\n", day, part, answer, submission.Verdict, source)
+	fmt.Fprintln(a.stdout, submission.Message)
+
+	if submission.Wait > 0 {
+		fmt.Fprintf(a.stdout, "Wait %s before submitting again\n", submission.Wait.Round(time.Second))
+	}
+
+	switch submission.Verdict {
+	case client.VerdictCorrect, client.VerdictAlreadySolved:
+		return nil
+	case client.VerdictUnknown, client.VerdictTooHigh, client.VerdictTooLow, client.VerdictIncorrect, client.VerdictTooSoon:
+		return fmt.Errorf("answer was not accepted: %s", submission.Verdict)
+	default:
+		return fmt.Errorf("answer was not accepted: %s", submission.Verdict)
+	}
+}
+
+// compare implements the compare subcommand, printing the total distance and
+// similarity score between every pair of columns in a multi-column day 1 input.
+func (a App) compare(args []string) error {
+	flags := a.flagSet("compare")
+	format := flags.String("format", "text", "Output format: text or csv")
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if *format != "text" && *format != "csv" {
+		return fmt.Errorf("unknown format %q, expected text or csv", *format)
+	}
+
+	if len(positional) > 1 {
+		return fmt.Errorf("compare expects at most 1 argument (a path), got %d", len(positional))
+	}
+
+	input, err := a.open(positional)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	columns, err := day01.ParseColumns(input)
+	if err != nil {
+		return err
+	}
+
+	comparison, err := day01.Compare(columns)
+	if err != nil {
+		return err
+	}
+
+	if *format == "csv" {
+		return comparison.WriteCSV(a.stdout)
+	}
+
+	return comparison.WriteText(a.stdout)
+}
+
+// monitor implements the monitor subcommand, classifying day 2 reports as they
+// stream in and printing running totals.
+func (a App) monitor(args []string) error {
+	flags := a.flagSet("monitor")
+	every := flags.Duration("every", time.Second, "Print running totals at most this often")
+	options := optionsFlag(flags)
+
+	positional, err := parseFlags(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) > 1 {
+		return fmt.Errorf("monitor expects at most 1 argument (a path), got %d", len(positional))
+	}
+
+	// Only the safety policy's options make sense when monitoring, reports are
+	// classified one at a time as they arrive
+	policy := day02.DefaultPolicy()
+	for _, option := range *options {
+		if err := policy.Set(option.Name, option.Value); err != nil {
+			return err
+		}
+	}
+
+	input, err := a.open(positional)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	monitor := day02.Monitor{Policy: &policy, Interval: *every}
+
+	return monitor.Run(input, a.stdout)
+}
+
+// open opens the file named by the only positional argument, or stdin if there
+// isn't one or it's "-".
+func (a App) open(positional []string) (io.ReadCloser, error) {
+	if len(positional) == 0 || positional[0] == "-" {
+		return io.NopCloser(a.stdin), nil
+	}
+
+	return os.Open(positional[0])
+}
+
+// solvePart solves the given part of a registered day against its embedded input.
+func solvePart(day, part int) (aoc.Answer, error) {
+	registered, err := days.Get(day)
+	if err != nil {
+		return "", err
+	}
+
+	// Only this part's error matters, part 2 may well not be written yet
+	result := runner.Run([]aoc.Day{registered}, 1).Days[0]
+	if result.Err != nil {
+		return "", fmt.Errorf("could not parse day %d input: %w", day, result.Err)
+	}
+
+	solved := result.Parts[part-1]
+	if solved.Err != nil {
+		return "", fmt.Errorf("could not solve day %d part %d: %w", day, part, solved.Err)
+	}
+
+	return solved.Answer, nil
+}
+
+// client returns an Advent of Code client using the user's session token.
+func (a App) client(cacheDir string) (*client.Client, error) {
+	session, err := client.Session()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.New(client.Config{BaseURL: a.baseURL, Session: session, CacheDir: cacheDir, Interval: a.interval})
+}
+
+// withInput overrides the day's embedded input with the contents of path, or
+// stdin if path is "-".
+//
+// If stream is true and the day can stream its input, it's read as the day is
+// solved rather than all at once up front, so it doesn't have to fit in memory.
+func (a App) withInput(day aoc.Day, path string, stream bool) (aoc.Day, error) {
+	open := func() (io.ReadCloser, error) {
+		if path == "-" {
+			return io.NopCloser(a.stdin), nil
+		}
+
+		return os.Open(path)
+	}
+
+	if stream {
+		return day.WithStream(open)
+	}
+
+	r, err := open()
+	if err != nil {
+		return aoc.Day{}, err
+	}
+	defer r.Close()
+
+	return day.WithInput(r)
+}
+
+// flagSet returns a new flag set for the named subcommand that reports
+// errors rather than exiting.
+func (a App) flagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(a.stderr)
+	flags.Usage = func() { fmt.Fprint(a.stderr, usage) }
+
+	return flags
+}
+
+// optionsFlag adds the repeatable --set flag to flags, returning the options it
+// collects.
+func optionsFlag(flags *flag.FlagSet) *[]aoc.Option {
+	var options []aoc.Option
+	flags.Func("set", "Set a day specific option as name=value", func(raw string) error {
+		option, err := aoc.ParseOption(raw)
+		if err != nil {
+			return err
+		}
+
+		options = append(options, option)
+
+		return nil
+	})
+
+	return &options
+}
+
+// parseFlags parses args with flags, allowing flags to appear before or after
+// positional arguments, and returns the positional arguments.
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// selectDays returns the days matching arg, which is either a day number
+// or the literal "all".
+func selectDays(arg string) ([]aoc.Day, error) {
+	if arg == "all" {
+		return days.All(), nil
+	}
+
+	number, err := strconv.Atoi(arg)
+	if err != nil {
+		return nil, fmt.Errorf("bad day %q, expected a number or 'all'", arg)
+	}
+
+	day, err := days.Get(number)
+	if err != nil {
+		return nil, err
+	}
+
+	return []aoc.Day{day}, nil
+}
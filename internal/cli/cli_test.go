@@ -0,0 +1,361 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FollowTheProcess/aoc2024/internal/client"
+	"github.com/FollowTheProcess/aoc2024/internal/fakeaoc"
+	"github.com/FollowTheProcess/test"
+)
+
+const exampleDay1 = `3   4
+4   3
+2   5
+1   3
+3   9
+3   3
+`
+
+func TestRun(t *testing.T) {
+	example := filepath.Join(t.TempDir(), "example.txt")
+	test.Ok(t, os.WriteFile(example, []byte(exampleDay1), 0o644))
+
+	tests := []struct {
+		name    string   // Name of the test case
+		stdin   string   // Contents of stdin
+		args    []string // Arguments passed to the CLI
+		want    string   // Substring expected in stdout
+		wantErr bool     // Whether we want an error
+	}{
+		{
+			name:    "no args",
+			args:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "unknown command",
+			args:    []string{"dance"},
+			wantErr: true,
+		},
+		{
+			name: "help",
+			args: []string{"help"},
+			want: "Usage:",
+		},
+		{
+			name: "single day",
+			args: []string{"run", "1"},
+			want: "2164381",
+		},
+		{
+			name: "all days",
+			args: []string{"run", "all"},
+			want: "71668682",
+		},
+		{
+			name: "flags after day",
+			args: []string{"run", "all", "--workers", "1"},
+			want: "71668682",
+		},
+		{
+			name: "input from file",
+			args: []string{"run", "1", "--input", example},
+			want: "31",
+		},
+		{
+			name:  "input from stdin",
+			stdin: exampleDay1,
+			args:  []string{"run", "1", "--input", "-"},
+			want:  "31",
+		},
+		{
+			name:  "input for a day that can't stream",
+			stdin: "xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))",
+			args:  []string{"run", "3", "--input", "-"},
+			want:  "161",
+		},
+		{
+			name: "options",
+			args: []string{"run", "2", "--set", "max-step=4", "--set", "removals=0"},
+			want: "622",
+		},
+		{
+			name:  "options all zero",
+			stdin: "5 5 5\n5 5 5\n1 2 3\n",
+			args:  []string{"run", "2", "--input", "-", "--set", "min-step=0", "--set", "max-step=0", "--set", "removals=0"},
+			want:  "2",
+		},
+		{
+			name:    "options unknown",
+			args:    []string{"run", "2", "--set", "colour=red"},
+			wantErr: true,
+		},
+		{
+			name:    "options malformed",
+			args:    []string{"run", "2", "--set", "max-step"},
+			wantErr: true,
+		},
+		{
+			name:    "options not supported",
+			args:    []string{"run", "3", "--set", "max-step=4"},
+			wantErr: true,
+		},
+		{
+			name:    "options all days",
+			args:    []string{"run", "all", "--set", "max-step=4"},
+			wantErr: true,
+		},
+		{
+			name:  "monitor stdin",
+			stdin: "7 6 4 2 1\n1 3 2 4 5\n",
+			args:  []string{"monitor", "--every", "0"},
+			want:  "Final: 2 reports, 1 safe, 2 safe with the dampener, 0 unsafe",
+		},
+		{
+			name:  "monitor options",
+			stdin: "1 2 7 8 9\n",
+			args:  []string{"monitor", "-", "--set", "max-step=5"},
+			want:  "Final: 1 reports, 1 safe",
+		},
+		{
+			name:  "monitor options all zero",
+			stdin: "5 5 5\n5 5 5\n1 2 3\n",
+			args:  []string{"monitor", "--set", "min-step=0", "--set", "max-step=0", "--set", "removals=0"},
+			want:  "Final: 3 reports, 2 safe, 2 safe with the dampener, 1 unsafe",
+		},
+		{
+			name:    "monitor bad option",
+			stdin:   "1 2 7 8 9\n",
+			args:    []string{"monitor", "--set", "colour=red"},
+			wantErr: true,
+		},
+		{
+			name:    "monitor workers option",
+			stdin:   "1 2 7 8 9\n",
+			args:    []string{"monitor", "--set", "workers=3"},
+			wantErr: true,
+		},
+		{
+			name:    "monitor bad report",
+			stdin:   "1 2 x\n",
+			args:    []string{"monitor"},
+			wantErr: true,
+		},
+		{
+			name: "compare file",
+			args: []string{"compare", example},
+			want: "Most similar: 1 and 2 (31)",
+		},
+		{
+			name:  "compare stdin csv",
+			stdin: exampleDay1,
+			args:  []string{"compare", "--format", "csv"},
+			want:  "1,2,11,31,true,true",
+		},
+		{
+			name:    "compare bad format",
+			args:    []string{"compare", example, "--format", "json"},
+			wantErr: true,
+		},
+		{
+			name:    "compare one column",
+			stdin:   "1\n2\n",
+			args:    []string{"compare", "-"},
+			wantErr: true,
+		},
+		{
+			name:    "input missing file",
+			args:    []string{"run", "1", "--input", filepath.Join(t.TempDir(), "missing.txt")},
+			wantErr: true,
+		},
+		{
+			name:    "input with all",
+			args:    []string{"run", "all", "--input", example},
+			wantErr: true,
+		},
+		{
+			name: "ndjson format",
+			args: []string{"run", "2", "--format", "ndjson"},
+			want: `{"answer":"598","year":2024,"day":2,"part":1,`,
+		},
+		{
+			name:    "bad format",
+			args:    []string{"run", "2", "--format", "xml"},
+			wantErr: true,
+		},
+		{
+			name: "verify all",
+			args: []string{"verify"},
+			want: "6 passed, 0 failed, 0 missing",
+		},
+		{
+			name: "verify one",
+			args: []string{"verify", "3", "--workers", "1"},
+			want: "2 passed, 0 failed, 0 missing",
+		},
+		{
+			name:    "verify too many args",
+			args:    []string{"verify", "1", "2"},
+			wantErr: true,
+		},
+		{
+			name:  "explain text",
+			stdin: exampleDay1,
+			args:  []string{"run", "1", "--input", "-", "--explain"},
+			want:  "Similarity score: 31",
+		},
+		{
+			name:  "explain json",
+			stdin: exampleDay1,
+			args:  []string{"run", "1", "--input", "-", "--explain", "--format", "json"},
+			want:  `"similarityScore": 31`,
+		},
+		{
+			name:  "explain ndjson",
+			stdin: exampleDay1,
+			args:  []string{"run", "1", "--input", "-", "--explain", "--format", "ndjson"},
+			want:  `{"pairs":[{"left":1,"right":3,"distance":2},`,
+		},
+		{
+			name:    "explain all",
+			args:    []string{"run", "all", "--explain"},
+			wantErr: true,
+		},
+		{
+			name:    "explain unsupported",
+			args:    []string{"run", "3", "--explain"},
+			wantErr: true,
+		},
+		{
+			name:    "bad flag",
+			args:    []string{"run", "--workers", "lots", "all"},
+			wantErr: true,
+		},
+		{
+			name:    "unregistered day",
+			args:    []string{"run", "26"},
+			wantErr: true,
+		},
+		{
+			name:    "bad day",
+			args:    []string{"run", "one"},
+			wantErr: true,
+		},
+		{
+			name:    "missing day",
+			args:    []string{"run"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			stderr := &bytes.Buffer{}
+
+			err := New(strings.NewReader(tt.stdin), stdout, stderr).Run(tt.args)
+			test.WantErr(t, err, tt.wantErr)
+
+			if !strings.Contains(stdout.String(), tt.want) {
+				t.Errorf("stdout %q did not contain %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDownload(t *testing.T) {
+	const session = "let-me-in"
+
+	server := fakeaoc.New(2024, session)
+	defer server.Close()
+	server.SetInput(4, "XMAS\n")
+
+	t.Setenv(client.SessionEnv, session)
+
+	root := t.TempDir()
+	test.Ok(t, os.MkdirAll(filepath.Join(root, "internal", "day04"), 0o755))
+
+	download := func(args ...string) (string, error) {
+		stdout := &bytes.Buffer{}
+		app := New(strings.NewReader(""), stdout, &bytes.Buffer{})
+		app.baseURL = server.URL
+		app.interval = time.Millisecond
+
+		args = append([]string{"download", "--root", root, "--cache-dir", filepath.Join(root, "cache")}, args...)
+		err := app.Run(args)
+
+		return stdout.String(), err
+	}
+
+	_, err := download("4")
+	test.Ok(t, err)
+
+	got, err := os.ReadFile(filepath.Join(root, "internal", "day04", "day04.txt"))
+	test.Ok(t, err)
+	test.Equal(t, string(got), "XMAS\n")
+
+	// Second time round the file has an input so we should refuse
+	_, err = download("4")
+	test.Err(t, err)
+
+	// But writing to stdout is fine, and comes from the cache
+	stdout, err := download("4", "--output", "-")
+	test.Ok(t, err)
+	test.Equal(t, stdout, "XMAS\n")
+	test.Equal(t, len(server.Requests()), 1)
+
+	// No package for day 5 yet
+	_, err = download("5")
+	test.Err(t, err)
+}
+
+func TestSubmit(t *testing.T) {
+	const session = "let-me-in"
+
+	server := fakeaoc.New(2024, session)
+	defer server.Close()
+	server.SetAnswer(1, 1, "2164381")
+	server.SetAnswer(1, 2, "20719933")
+
+	t.Setenv(client.SessionEnv, session)
+	cacheDir := t.TempDir()
+
+	submit := func(args ...string) (string, error) {
+		stdout := &bytes.Buffer{}
+		app := New(strings.NewReader(""), stdout, &bytes.Buffer{})
+		app.baseURL = server.URL
+		app.interval = time.Millisecond
+
+		args = append([]string{"submit", "--cache-dir", cacheDir}, args...)
+		err := app.Run(args)
+
+		return stdout.String(), err
+	}
+
+	// No answer given so it should solve day 1 part 1 itself
+	stdout, err := submit("1", "1")
+	test.Ok(t, err)
+	test.True(t, strings.Contains(stdout, "2164381 is correct (from adventofcode.com)"))
+
+	stdout, err = submit("1", "1")
+	test.Ok(t, err)
+	test.True(t, strings.Contains(stdout, "2164381 is correct (from past guesses)"))
+
+	stdout, err = submit("1", "2", "1")
+	test.Err(t, err)
+	test.True(t, strings.Contains(stdout, "1 is too low"))
+	test.True(t, strings.Contains(stdout, "Wait 1m0s before submitting again"))
+
+	_, err = submit("1", "3")
+	test.Err(t, err)
+
+	_, err = submit("1")
+	test.Err(t, err)
+
+	test.Equal(t, len(server.Requests()), 2)
+}
@@ -0,0 +1,276 @@
+// Package client implements a polite client for the Advent of Code website, used
+// to download puzzle inputs and submit answers.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+)
+
+const (
+	// DefaultBaseURL is the URL of the real Advent of Code website.
+	DefaultBaseURL = "https://adventofcode.com"
+
+	// DefaultInterval is the default minimum time between requests to the website.
+	DefaultInterval = 3 * time.Second
+
+	// DefaultTimeout is how long the default HTTP client waits for a response before
+	// giving up.
+	DefaultTimeout = 30 * time.Second
+
+	// lastRequestFile is the name of the file in the cache directory recording when
+	// the last request was made, so the rate limit holds across separate runs.
+	lastRequestFile = "last-request"
+
+	// UserAgent identifies this client to the website, as its maintainer asks
+	// automated tools to do.
+	UserAgent = "github.com/FollowTheProcess/aoc2024"
+
+	// SessionEnv is the environment variable holding the session token.
+	SessionEnv = "AOC_SESSION"
+
+	// maxErrorBody is the most of an error response body included in an error.
+	maxErrorBody = 200
+)
+
+// Config configures a Client, the zero value of any field means use the default.
+type Config struct {
+	HTTPClient *http.Client  // The HTTP client to use, default one with a DefaultTimeout timeout
+	BaseURL    string        // The website URL, default DefaultBaseURL
+	Session    string        // The session cookie value, required
+	CacheDir   string        // Where to cache inputs, default <user cache dir>/aoc
+	Interval   time.Duration // Minimum time between requests, default DefaultInterval
+}
+
+// Client talks to the Advent of Code website.
+//
+// A Client is safe for concurrent use, requests are serialised so that no two are
+// ever made less than the configured interval apart. The time of the last request
+// is kept in the cache directory too, so the interval also holds between separate
+// Clients sharing it, like back to back runs of the CLI.
+type Client struct {
+	last     time.Time // When the last request was made by this Client
+	http     *http.Client
+	baseURL  string
+	session  string
+	cacheDir string
+	interval time.Duration
+	mu       sync.Mutex // Protects last and serialises requests
+	guessMu  sync.Mutex // Serialises submissions so the guess log stays consistent
+}
+
+// New returns a new Client with the given config.
+func New(cfg Config) (*Client, error) {
+	if cfg.Session == "" {
+		return nil, fmt.Errorf("no session token, set $%s or save it to %s", SessionEnv, sessionFileHint())
+	}
+
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = DefaultBaseURL
+	}
+
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultInterval
+	}
+
+	if cfg.CacheDir == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("could not locate cache directory: %w", err)
+		}
+		cfg.CacheDir = filepath.Join(dir, "aoc")
+	}
+
+	return &Client{
+		http:     cfg.HTTPClient,
+		baseURL:  strings.TrimSuffix(cfg.BaseURL, "/"),
+		session:  cfg.Session,
+		cacheDir: cfg.CacheDir,
+		interval: cfg.Interval,
+	}, nil
+}
+
+// Session returns the session token from $AOC_SESSION, falling back to the
+// "aoc/session" file in the user's config directory.
+func Session() (string, error) {
+	if session := strings.TrimSpace(os.Getenv(SessionEnv)); session != "" {
+		return session, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("no $%s set and could not locate config directory: %w", SessionEnv, err)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "aoc", "session"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("no session token, set $%s or save it to %s", SessionEnv, sessionFileHint())
+		}
+		return "", err
+	}
+
+	return strings.TrimSpace(string(raw)), nil
+}
+
+// Input returns the puzzle input for the given day.
+//
+// Inputs never change once released, so an input is only ever downloaded once
+// and served from the cache from then on.
+func (c *Client) Input(ctx context.Context, day int) (string, error) {
+	if day < 1 || day > 25 {
+		return "", fmt.Errorf("invalid day %d, must be between 1 and 25", day)
+	}
+
+	path := filepath.Join(c.cacheDir, fmt.Sprint(aoc.Year), fmt.Sprintf("day%02d.txt", day))
+
+	cached, err := os.ReadFile(path)
+	if err == nil {
+		return string(cached), nil
+	}
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("could not read cached input: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/%d/day/%d/input", c.baseURL, aoc.Year, day)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := c.do(request)
+	if err != nil {
+		return "", fmt.Errorf("could not download input for day %d: %w", day, err)
+	}
+
+	if err := writeCache(path, body); err != nil {
+		return "", fmt.Errorf("could not cache input for day %d: %w", day, err)
+	}
+
+	return string(body), nil
+}
+
+// do sends the request once the rate limit allows, returning the body of a
+// successful response.
+func (c *Client) do(request *http.Request) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := c.last
+	if recorded := c.lastRequest(); recorded.After(last) {
+		last = recorded
+	}
+
+	if wait := c.interval - time.Since(last); !last.IsZero() && wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	request.Header.Set("User-Agent", UserAgent)
+	request.AddCookie(&http.Cookie{Name: "session", Value: c.session})
+
+	// Record the request before making it, so if it can't be recorded the rate
+	// limit can't be silently skipped next time
+	c.last = time.Now()
+	if err := writeCache(c.lastRequestPath(), []byte(c.last.Format(time.RFC3339Nano))); err != nil {
+		return nil, fmt.Errorf("could not record request time: %w", err)
+	}
+
+	response, err := c.http.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		snippet := strings.TrimSpace(string(body))
+		if len(snippet) > maxErrorBody {
+			snippet = snippet[:maxErrorBody] + "..."
+		}
+		return nil, fmt.Errorf("%s: %s", response.Status, snippet)
+	}
+
+	return body, nil
+}
+
+// lastRequest returns when the last request was made by any Client sharing the
+// cache directory, or the zero time if it's not known.
+func (c *Client) lastRequest() time.Time {
+	raw, err := os.ReadFile(c.lastRequestPath())
+	if err != nil {
+		return time.Time{}
+	}
+
+	last, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(raw)))
+	if err != nil {
+		return time.Time{}
+	}
+
+	return last
+}
+
+// lastRequestPath returns the path to the file recording the last request time.
+func (c *Client) lastRequestPath() string {
+	return filepath.Join(c.cacheDir, lastRequestFile)
+}
+
+// writeCache atomically writes contents to path, so an interrupted download
+// never leaves a partial input in the cache.
+func writeCache(path string, contents []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// sessionFileHint returns where the session file is expected, for error messages.
+func sessionFileHint() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return filepath.Join("<config dir>", "aoc", "session")
+	}
+
+	return filepath.Join(dir, "aoc", "session")
+}
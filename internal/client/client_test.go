@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FollowTheProcess/aoc2024/internal/fakeaoc"
+	"github.com/FollowTheProcess/test"
+)
+
+const session = "53616c7465645f5f"
+
+// newClient returns a client pointed at a fresh fake server, with a short
+// interval so tests stay fast.
+func newClient(t *testing.T) (*Client, *fakeaoc.Server) {
+	t.Helper()
+
+	server := fakeaoc.New(2024, session)
+	t.Cleanup(server.Close)
+
+	client, err := New(Config{
+		BaseURL:    server.URL,
+		Session:    session,
+		CacheDir:   t.TempDir(),
+		Interval:   time.Millisecond,
+		HTTPClient: server.Client(),
+	})
+	test.Ok(t, err)
+
+	return client, server
+}
+
+func TestNewNoSession(t *testing.T) {
+	_, err := New(Config{})
+	test.Err(t, err)
+}
+
+func TestInput(t *testing.T) {
+	client, server := newClient(t)
+	server.SetInput(1, "3   4\n4   3\n")
+
+	input, err := client.Input(context.Background(), 1)
+	test.Ok(t, err)
+	test.Equal(t, input, "3   4\n4   3\n")
+
+	requests := server.Requests()
+	test.Equal(t, len(requests), 1)
+	test.Equal(t, requests[0].Path, "/2024/day/1/input")
+	test.Equal(t, requests[0].UserAgent, UserAgent)
+
+	cached, err := os.ReadFile(filepath.Join(client.cacheDir, "2024", "day01.txt"))
+	test.Ok(t, err)
+	test.Equal(t, string(cached), input)
+}
+
+func TestInputCached(t *testing.T) {
+	client, server := newClient(t)
+	server.SetInput(2, "7 6 4 2 1\n")
+
+	for range 5 {
+		input, err := client.Input(context.Background(), 2)
+		test.Ok(t, err)
+		test.Equal(t, input, "7 6 4 2 1\n")
+	}
+
+	test.Equal(t, len(server.Requests()), 1) // Input should only be downloaded once
+}
+
+func TestInputErrors(t *testing.T) {
+	t.Run("invalid day", func(t *testing.T) {
+		client, server := newClient(t)
+
+		_, err := client.Input(context.Background(), 26)
+		test.Err(t, err)
+		test.Equal(t, len(server.Requests()), 0)
+	})
+
+	t.Run("not unlocked", func(t *testing.T) {
+		client, _ := newClient(t)
+
+		_, err := client.Input(context.Background(), 25)
+		test.Err(t, err)
+		test.True(t, strings.Contains(err.Error(), "404"))
+
+		// Failed downloads must never be cached
+		_, err = os.Stat(filepath.Join(client.cacheDir, "2024", "day25.txt"))
+		test.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("bad session", func(t *testing.T) {
+		client, server := newClient(t)
+		server.SetInput(1, "input")
+		client.session = "wrong"
+
+		_, err := client.Input(context.Background(), 1)
+		test.Err(t, err)
+		test.True(t, strings.Contains(err.Error(), "Please log in"))
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	client, server := newClient(t)
+	client.interval = 50 * time.Millisecond
+
+	for day := 1; day <= 3; day++ {
+		server.SetInput(day, "input")
+	}
+
+	start := time.Now()
+	for day := 1; day <= 3; day++ {
+		_, err := client.Input(context.Background(), day)
+		test.Ok(t, err)
+	}
+
+	// 3 requests means 2 waits of at least the interval
+	if elapsed := time.Since(start); elapsed < 2*client.interval {
+		t.Errorf("3 requests took %s, expected at least %s", elapsed, 2*client.interval)
+	}
+}
+
+func TestRateLimitAcrossClients(t *testing.T) {
+	first, server := newClient(t)
+	server.SetInput(1, "input")
+	server.SetInput(2, "input")
+
+	// A second client sharing the cache, like the next run of the CLI
+	second, err := New(Config{
+		BaseURL:    server.URL,
+		Session:    session,
+		CacheDir:   first.cacheDir,
+		Interval:   50 * time.Millisecond,
+		HTTPClient: server.Client(),
+	})
+	test.Ok(t, err)
+
+	_, err = first.Input(context.Background(), 1)
+	test.Ok(t, err)
+
+	start := time.Now()
+	_, err = second.Input(context.Background(), 2)
+	test.Ok(t, err)
+
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("second client's request took %s, expected it to wait for the first's", elapsed)
+	}
+}
+
+func TestRateLimitCorruptRecord(t *testing.T) {
+	client, server := newClient(t)
+	server.SetInput(1, "input")
+
+	test.Ok(t, os.WriteFile(filepath.Join(client.cacheDir, lastRequestFile), []byte("nonsense"), 0o644))
+
+	_, err := client.Input(context.Background(), 1)
+	test.Ok(t, err)
+
+	raw, err := os.ReadFile(filepath.Join(client.cacheDir, lastRequestFile))
+	test.Ok(t, err)
+
+	recorded, err := time.Parse(time.RFC3339Nano, string(raw))
+	test.Ok(t, err)
+	test.True(t, time.Since(recorded) < time.Minute)
+}
+
+func TestNewDefaultTimeout(t *testing.T) {
+	client, err := New(Config{Session: session, CacheDir: t.TempDir()})
+	test.Ok(t, err)
+	test.Equal(t, client.http.Timeout, DefaultTimeout)
+}
+
+func TestRateLimitCancelled(t *testing.T) {
+	client, server := newClient(t)
+	client.interval = time.Hour
+
+	server.SetInput(1, "input")
+	server.SetInput(2, "input")
+
+	_, err := client.Input(context.Background(), 1)
+	test.Ok(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Input(ctx, 2)
+	test.Err(t, err)
+	test.Equal(t, len(server.Requests()), 1)
+}
+
+func TestSession(t *testing.T) {
+	// Point every OS's notion of the config directory somewhere we control
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("AppData", filepath.Join(home, "AppData"))
+
+	t.Run("env", func(t *testing.T) {
+		t.Setenv(SessionEnv, " from-env\n")
+
+		got, err := Session()
+		test.Ok(t, err)
+		test.Equal(t, got, "from-env")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		t.Setenv(SessionEnv, "")
+
+		_, err := Session()
+		test.Err(t, err)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		t.Setenv(SessionEnv, "")
+
+		dir, err := os.UserConfigDir()
+		test.Ok(t, err)
+		test.Ok(t, os.MkdirAll(filepath.Join(dir, "aoc"), 0o755))
+		test.Ok(t, os.WriteFile(filepath.Join(dir, "aoc", "session"), []byte("from-file\n"), 0o600))
+
+		got, err := Session()
+		test.Ok(t, err)
+		test.Equal(t, got, "from-file")
+	})
+}
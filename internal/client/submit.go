@@ -0,0 +1,361 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+)
+
+// Verdict is the website's judgement of a submitted answer.
+type Verdict int
+
+const (
+	// VerdictUnknown means the response could not be understood.
+	VerdictUnknown Verdict = iota
+
+	// VerdictCorrect means the answer was right.
+	VerdictCorrect
+
+	// VerdictTooHigh means the answer was wrong, and too high.
+	VerdictTooHigh
+
+	// VerdictTooLow means the answer was wrong, and too low.
+	VerdictTooLow
+
+	// VerdictIncorrect means the answer was wrong, with no hint as to why.
+	VerdictIncorrect
+
+	// VerdictTooSoon means an answer was given too recently, try again after the
+	// Submission's Wait.
+	VerdictTooSoon
+
+	// VerdictAlreadySolved means the part has already been solved.
+	VerdictAlreadySolved
+)
+
+// String implements [fmt.Stringer] for a Verdict.
+func (v Verdict) String() string {
+	switch v {
+	case VerdictUnknown:
+		return "unknown"
+	case VerdictCorrect:
+		return "correct"
+	case VerdictTooHigh:
+		return "too high"
+	case VerdictTooLow:
+		return "too low"
+	case VerdictIncorrect:
+		return "incorrect"
+	case VerdictTooSoon:
+		return "too soon"
+	case VerdictAlreadySolved:
+		return "already solved"
+	default:
+		return fmt.Sprintf("Verdict(%d)", int(v))
+	}
+}
+
+// MarshalText implements [encoding.TextMarshaler] so the guess log stays readable
+// and doesn't depend on the order of the constants.
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler] for a Verdict.
+func (v *Verdict) UnmarshalText(text []byte) error {
+	for candidate := VerdictUnknown; candidate <= VerdictAlreadySolved; candidate++ {
+		if candidate.String() == string(text) {
+			*v = candidate
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown verdict %q", text)
+}
+
+// Final reports whether the verdict is a definitive judgement of the answer, and
+// so is worth remembering.
+func (v Verdict) Final() bool {
+	switch v {
+	case VerdictCorrect, VerdictTooHigh, VerdictTooLow, VerdictIncorrect:
+		return true
+	case VerdictUnknown, VerdictTooSoon, VerdictAlreadySolved:
+		return false
+	default:
+		return false
+	}
+}
+
+// Submission is the outcome of submitting an answer.
+type Submission struct {
+	Message string        // The website's message, or why the answer wasn't sent
+	Verdict Verdict       // The judgement of the answer
+	Wait    time.Duration // How long until another answer may be submitted, if known
+	Cached  bool          // Whether the verdict came from past guesses without asking the website
+}
+
+// Guess is a previously submitted answer and its verdict.
+type Guess struct {
+	Time    time.Time  `json:"time"`    // When it was submitted
+	Answer  aoc.Answer `json:"answer"`  // The answer submitted
+	Part    int        `json:"part"`    // The part it was for
+	Verdict Verdict    `json:"verdict"` // The website's verdict
+}
+
+// guessLog is the on disk record of every guess for a single day.
+type guessLog struct {
+	WaitUntil time.Time `json:"waitUntil"` // No answers should be sent before this time
+	Guesses   []Guess   `json:"guesses"`   // Every guess with a final verdict, oldest first
+}
+
+var (
+	articleRegex = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegex     = regexp.MustCompile(`<[^>]+>`)
+	spaceRegex   = regexp.MustCompile(`\s+`)
+	leftRegex    = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	penaltyRegex = regexp.MustCompile(`(?i)please wait (\w+) minutes? before trying again`)
+)
+
+// numberWords are the spelled out numbers the website uses for wrong answer penalties.
+var numberWords = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+}
+
+// Submit submits an answer for one part of a day.
+//
+// Every final verdict is recorded in the cache so that an answer is never sent
+// twice, and answers on the wrong side of a known too high or too low guess are
+// rejected without being sent. Likewise if the website has asked us to wait, no
+// answer is sent until that wait is over.
+func (c *Client) Submit(ctx context.Context, day, part int, answer aoc.Answer) (Submission, error) {
+	if day < 1 || day > 25 {
+		return Submission{}, fmt.Errorf("invalid day %d, must be between 1 and 25", day)
+	}
+
+	if part != 1 && part != 2 {
+		return Submission{}, fmt.Errorf("invalid part %d, must be 1 or 2", part)
+	}
+
+	answer = aoc.Answer(strings.TrimSpace(answer.String()))
+	if answer == "" {
+		return Submission{}, errors.New("refusing to submit an empty answer")
+	}
+
+	// Hold the lock across the whole read-submit-write so concurrent submissions
+	// can't both miss each other's guesses
+	c.guessMu.Lock()
+	defer c.guessMu.Unlock()
+
+	path := filepath.Join(c.cacheDir, fmt.Sprint(aoc.Year), fmt.Sprintf("day%02d.guesses.json", day))
+
+	log, err := readGuesses(path)
+	if err != nil {
+		return Submission{}, err
+	}
+
+	if submission, ok := log.check(part, answer, time.Now()); ok {
+		return submission, nil
+	}
+
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer.String()}}
+	endpoint := fmt.Sprintf("%s/%d/day/%d/answer", c.baseURL, aoc.Year, day)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Submission{}, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := c.do(request)
+	if err != nil {
+		return Submission{}, fmt.Errorf("could not submit answer for day %d part %d: %w", day, part, err)
+	}
+
+	submission := ParseResponse(string(body))
+
+	now := time.Now()
+	if submission.Verdict.Final() {
+		log.Guesses = append(log.Guesses, Guess{Time: now, Answer: answer, Part: part, Verdict: submission.Verdict})
+	}
+
+	if submission.Wait > 0 {
+		log.WaitUntil = now.Add(submission.Wait)
+	}
+
+	if err := writeGuesses(path, log); err != nil {
+		return submission, fmt.Errorf("answer submitted but could not record it: %w", err)
+	}
+
+	return submission, nil
+}
+
+// ParseResponse parses the website's response page to a submitted answer.
+func ParseResponse(page string) Submission {
+	message := page
+	if match := articleRegex.FindStringSubmatch(page); match != nil {
+		message = match[1]
+	}
+
+	message = tagRegex.ReplaceAllString(message, "")
+	message = strings.TrimSpace(spaceRegex.ReplaceAllString(message, " "))
+
+	submission := Submission{Message: message, Wait: parseWait(message)}
+
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		submission.Verdict = VerdictCorrect
+	case strings.Contains(message, "your answer is too high"):
+		submission.Verdict = VerdictTooHigh
+	case strings.Contains(message, "your answer is too low"):
+		submission.Verdict = VerdictTooLow
+	case strings.Contains(message, "That's not the right answer"):
+		submission.Verdict = VerdictIncorrect
+	case strings.Contains(message, "You gave an answer too recently"):
+		submission.Verdict = VerdictTooSoon
+	case strings.Contains(message, "Did you already complete it"):
+		submission.Verdict = VerdictAlreadySolved
+	default:
+		submission.Verdict = VerdictUnknown
+	}
+
+	return submission
+}
+
+// parseWait returns how long the message asks us to wait before answering again,
+// or 0 if it doesn't say.
+func parseWait(message string) time.Duration {
+	if match := leftRegex.FindStringSubmatch(message); match != nil {
+		minutes, _ := strconv.Atoi(match[1]) // Optional, so "" is 0 minutes
+		seconds, _ := strconv.Atoi(match[2]) // Regex guarantees digits
+		return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	}
+
+	if match := penaltyRegex.FindStringSubmatch(message); match != nil {
+		word := strings.ToLower(match[1])
+		minutes, ok := numberWords[word]
+		if !ok {
+			n, err := strconv.Atoi(word)
+			if err != nil {
+				return 0
+			}
+			minutes = n
+		}
+		return time.Duration(minutes) * time.Minute
+	}
+
+	return 0
+}
+
+// check answers a submission from past guesses if possible, reporting whether
+// it could. Nothing should be sent to the website if it did.
+//
+// A repeat of a past guess gets its verdict again, then a part that has been
+// solved is reported as such before any too high or too low bound is applied.
+func (g guessLog) check(part int, answer aoc.Answer, now time.Time) (Submission, bool) {
+	var solved *Guess
+	for i, guess := range g.Guesses {
+		if guess.Part != part {
+			continue
+		}
+
+		if guess.Answer == answer {
+			return Submission{
+				Verdict: guess.Verdict,
+				Message: fmt.Sprintf("already submitted %s at %s", answer, guess.Time.Format(time.DateTime)),
+				Cached:  true,
+			}, true
+		}
+
+		if guess.Verdict == VerdictCorrect {
+			solved = &g.Guesses[i]
+		}
+	}
+
+	if solved != nil {
+		return Submission{
+			Verdict: VerdictAlreadySolved,
+			Message: fmt.Sprintf("part %d was already solved with %s", part, solved.Answer),
+			Cached:  true,
+		}, true
+	}
+
+	numeric, numericErr := strconv.Atoi(answer.String())
+	for _, guess := range g.Guesses {
+		if guess.Part != part {
+			continue
+		}
+
+		previous, err := strconv.Atoi(guess.Answer.String())
+		if numericErr != nil || err != nil {
+			continue
+		}
+
+		if guess.Verdict == VerdictTooHigh && numeric >= previous {
+			return Submission{
+				Verdict: VerdictTooHigh,
+				Message: fmt.Sprintf("%s was already too high", guess.Answer),
+				Cached:  true,
+			}, true
+		}
+
+		if guess.Verdict == VerdictTooLow && numeric <= previous {
+			return Submission{
+				Verdict: VerdictTooLow,
+				Message: fmt.Sprintf("%s was already too low", guess.Answer),
+				Cached:  true,
+			}, true
+		}
+	}
+
+	if wait := g.WaitUntil.Sub(now); wait > 0 {
+		return Submission{
+			Verdict: VerdictTooSoon,
+			Message: "still waiting after the last wrong answer",
+			Wait:    wait,
+			Cached:  true,
+		}, true
+	}
+
+	return Submission{}, false
+}
+
+// readGuesses reads the guess log at path, returning an empty log if there isn't one.
+func readGuesses(path string) (guessLog, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return guessLog{}, nil
+		}
+		return guessLog{}, fmt.Errorf("could not read past guesses: %w", err)
+	}
+
+	var log guessLog
+	if err := json.Unmarshal(raw, &log); err != nil {
+		return guessLog{}, fmt.Errorf("could not parse past guesses in %s: %w", path, err)
+	}
+
+	return log, nil
+}
+
+// writeGuesses writes the guess log to path.
+func writeGuesses(path string, log guessLog) error {
+	raw, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeCache(path, raw)
+}
@@ -0,0 +1,281 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/test"
+)
+
+// recorded returns one of the fake server's recorded response pages, filled in.
+func recorded(t *testing.T, name, wait string) string {
+	t.Helper()
+
+	raw, err := os.ReadFile(filepath.Join("..", "fakeaoc", "responses", name+".html"))
+	test.Ok(t, err)
+
+	return strings.NewReplacer("{{DAY}}", "1", "{{WAIT}}", wait).Replace(string(raw))
+}
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		name    string        // Name of the test case
+		page    string        // The response page
+		message string        // Expected start of the message
+		verdict Verdict       // Expected verdict
+		wait    time.Duration // Expected wait
+	}{
+		{
+			name:    "correct",
+			page:    recorded(t, "correct", ""),
+			verdict: VerdictCorrect,
+			message: "That's the right answer! You are one gold star closer",
+		},
+		{
+			name:    "too high",
+			page:    recorded(t, "too_high", ""),
+			verdict: VerdictTooHigh,
+			wait:    time.Minute,
+			message: "That's not the right answer; your answer is too high.",
+		},
+		{
+			name:    "too low",
+			page:    recorded(t, "too_low", ""),
+			verdict: VerdictTooLow,
+			wait:    time.Minute,
+			message: "That's not the right answer; your answer is too low.",
+		},
+		{
+			name:    "incorrect",
+			page:    recorded(t, "incorrect", ""),
+			verdict: VerdictIncorrect,
+			wait:    time.Minute,
+			message: "That's not the right answer.",
+		},
+		{
+			name:    "too recent seconds",
+			page:    recorded(t, "too_recent", "43s"),
+			verdict: VerdictTooSoon,
+			wait:    43 * time.Second,
+			message: "You gave an answer too recently",
+		},
+		{
+			name:    "too recent minutes",
+			page:    recorded(t, "too_recent", "4m 2s"),
+			verdict: VerdictTooSoon,
+			wait:    4*time.Minute + 2*time.Second,
+			message: "You gave an answer too recently",
+		},
+		{
+			name:    "already solved",
+			page:    recorded(t, "already_solved", ""),
+			verdict: VerdictAlreadySolved,
+			message: "You don't seem to be solving the right level. Did you",
+		},
+		{
+			name:    "longer penalty",
+			page:    "<article><p>That's not the right answer.  Please wait 5 minutes before trying again.</p></article>",
+			verdict: VerdictIncorrect,
+			wait:    5 * time.Minute,
+			message: "That's not the right answer.",
+		},
+		{
+			name:    "gibberish",
+			page:    "<html>Something went wrong</html>",
+			verdict: VerdictUnknown,
+			message: "Something went wrong",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseResponse(tt.page)
+			test.Equal(t, got.Verdict, tt.verdict)
+			test.Equal(t, got.Wait, tt.wait)
+			test.Equal(t, got.Cached, false)
+
+			if !strings.HasPrefix(got.Message, tt.message) {
+				t.Errorf("message %q did not start with %q", got.Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	t.Run("correct", func(t *testing.T) {
+		client, server := newClient(t)
+		server.SetAnswer(1, 1, "11")
+
+		got, err := client.Submit(context.Background(), 1, 1, "11")
+		test.Ok(t, err)
+		test.Equal(t, got.Verdict, VerdictCorrect)
+		test.False(t, got.Cached)
+
+		// Resubmitting is answered from the guess log
+		got, err = client.Submit(context.Background(), 1, 1, "11")
+		test.Ok(t, err)
+		test.Equal(t, got.Verdict, VerdictCorrect)
+		test.True(t, got.Cached)
+
+		// As is any other answer now we know the right one
+		got, err = client.Submit(context.Background(), 1, 1, "12")
+		test.Ok(t, err)
+		test.Equal(t, got.Verdict, VerdictAlreadySolved)
+		test.True(t, got.Cached)
+
+		test.Equal(t, len(server.Requests()), 1)
+	})
+
+	t.Run("wrong then wait", func(t *testing.T) {
+		client, server := newClient(t)
+		server.SetAnswer(2, 1, "100")
+
+		got, err := client.Submit(context.Background(), 2, 1, aoc.Int(150))
+		test.Ok(t, err)
+		test.Equal(t, got.Verdict, VerdictTooHigh)
+		test.Equal(t, got.Wait, time.Minute)
+
+		// We've been told to wait so shouldn't try again yet
+		got, err = client.Submit(context.Background(), 2, 1, aoc.Int(50))
+		test.Ok(t, err)
+		test.Equal(t, got.Verdict, VerdictTooSoon)
+		test.True(t, got.Cached)
+
+		test.Equal(t, len(server.Requests()), 1)
+	})
+
+	t.Run("server says too soon", func(t *testing.T) {
+		client, server := newClient(t)
+		server.SetAnswer(3, 2, "right")
+
+		// Someone else (or another machine) got it wrong first
+		other, err := New(Config{
+			BaseURL:    server.URL,
+			Session:    session,
+			CacheDir:   t.TempDir(),
+			Interval:   time.Millisecond,
+			HTTPClient: server.Client(),
+		})
+		test.Ok(t, err)
+
+		got, err := other.Submit(context.Background(), 3, 2, "wrong")
+		test.Ok(t, err)
+		test.Equal(t, got.Verdict, VerdictIncorrect)
+
+		got, err = client.Submit(context.Background(), 3, 2, "right")
+		test.Ok(t, err)
+		test.Equal(t, got.Verdict, VerdictTooSoon)
+		test.False(t, got.Cached)
+		test.True(t, got.Wait > 50*time.Second)
+
+		// Too soon isn't a verdict on the answer so must not be remembered as one
+		log, err := readGuesses(filepath.Join(client.cacheDir, "2024", "day03.guesses.json"))
+		test.Ok(t, err)
+		test.Equal(t, len(log.Guesses), 0)
+		test.True(t, log.WaitUntil.After(time.Now()))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		client, server := newClient(t)
+
+		_, err := client.Submit(context.Background(), 0, 1, "1")
+		test.Err(t, err)
+
+		_, err = client.Submit(context.Background(), 1, 3, "1")
+		test.Err(t, err)
+
+		_, err = client.Submit(context.Background(), 1, 1, "  ")
+		test.Err(t, err)
+
+		test.Equal(t, len(server.Requests()), 0)
+	})
+}
+
+func TestGuessLogCheck(t *testing.T) {
+	now := time.Now()
+	log := guessLog{
+		Guesses: []Guess{
+			{Part: 1, Answer: "100", Verdict: VerdictTooHigh},
+			{Part: 1, Answer: "20", Verdict: VerdictTooLow},
+			{Part: 1, Answer: "50", Verdict: VerdictIncorrect},
+			{Part: 2, Answer: "abc", Verdict: VerdictCorrect},
+		},
+	}
+
+	tests := []struct {
+		name    string     // Name of the test case
+		answer  aoc.Answer // The answer to check
+		verdict Verdict    // Expected cached verdict, if known
+		part    int        // The part to check
+		known   bool       // Whether the guess log should answer it
+	}{
+		{name: "repeat too high", part: 1, answer: "100", verdict: VerdictTooHigh, known: true},
+		{name: "above too high", part: 1, answer: "150", verdict: VerdictTooHigh, known: true},
+		{name: "below too low", part: 1, answer: "3", verdict: VerdictTooLow, known: true},
+		{name: "equal too low", part: 1, answer: "20", verdict: VerdictTooLow, known: true},
+		{name: "repeat incorrect", part: 1, answer: "50", verdict: VerdictIncorrect, known: true},
+		{name: "in range", part: 1, answer: "51", known: false},
+		{name: "not numeric", part: 1, answer: "fifty", known: false},
+		{name: "already solved", part: 2, answer: "def", verdict: VerdictAlreadySolved, known: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, known := log.check(tt.part, tt.answer, now)
+			test.Equal(t, known, tt.known)
+			test.Equal(t, got.Verdict, tt.verdict)
+		})
+	}
+
+	t.Run("solved after a bound", func(t *testing.T) {
+		solved := guessLog{
+			Guesses: []Guess{
+				{Part: 1, Answer: "100", Verdict: VerdictTooHigh},
+				{Part: 1, Answer: "5", Verdict: VerdictTooLow},
+				{Part: 1, Answer: "50", Verdict: VerdictCorrect},
+			},
+		}
+
+		for _, answer := range []aoc.Answer{"200", "1", "60"} {
+			got, known := solved.check(1, answer, now)
+			test.True(t, known)
+			test.Equal(t, got.Verdict, VerdictAlreadySolved)
+		}
+
+		// Repeats still get their own verdict
+		got, known := solved.check(1, "100", now)
+		test.True(t, known)
+		test.Equal(t, got.Verdict, VerdictTooHigh)
+	})
+
+	t.Run("waiting", func(t *testing.T) {
+		waiting := guessLog{WaitUntil: now.Add(30 * time.Second)}
+
+		got, known := waiting.check(1, "51", now)
+		test.True(t, known)
+		test.Equal(t, got.Verdict, VerdictTooSoon)
+		test.Equal(t, got.Wait, 30*time.Second)
+
+		_, known = waiting.check(1, "51", now.Add(time.Minute))
+		test.False(t, known)
+	})
+}
+
+func TestVerdictText(t *testing.T) {
+	for verdict := VerdictUnknown; verdict <= VerdictAlreadySolved; verdict++ {
+		text, err := verdict.MarshalText()
+		test.Ok(t, err)
+
+		var got Verdict
+		test.Ok(t, got.UnmarshalText(text))
+		test.Equal(t, got, verdict)
+	}
+
+	var bad Verdict
+	test.Err(t, bad.UnmarshalText([]byte("maybe")))
+}
@@ -0,0 +1,348 @@
+package day01
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"iter"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/FollowTheProcess/aoc2024/internal/parse"
+)
+
+// ParseColumns parses any number of whitespace separated columns of location IDs
+// from r, one row per line, returning each column as a list.
+//
+// It's the generalisation of the two list puzzle input for reconciling more than
+// two historians' lists. Blank lines are skipped but every other line must have
+// as many IDs as the first.
+func ParseColumns(r io.Reader) ([][]int, error) {
+	return parseColumns(r, 0)
+}
+
+// parseColumns parses columns of location IDs from r like ParseColumns, but if want
+// is more than 0 every line must have exactly that many.
+func parseColumns(r io.Reader, want int) ([][]int, error) {
+	rows := newRowReader(r, want)
+
+	var columns [][]int
+	for row := range rows.All() {
+		if columns == nil {
+			columns = make([][]int, len(row))
+		}
+
+		for i, id := range row {
+			columns[i] = append(columns[i], id)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// rowReader reads rows of whitespace separated location IDs from a stream one line
+// at a time, so lists far too big to hold in memory can still be processed. It's
+// the one parser behind the puzzle's two lists, [Pairs] and [ParseColumns].
+//
+// It works like a [bufio.Scanner], iterate with All and then check Err.
+type rowReader struct {
+	scanner *bufio.Scanner // Splits the stream into lines
+	err     error          // The first error encountered
+	fields  []field        // The fields of the current line, reused for every line
+	row     []int          // The IDs of the current line, reused for every line
+	columns int            // How many IDs every line must have, 0 until the first line if not fixed
+	lineNo  int            // The line number of the most recently read line
+}
+
+// newRowReader returns a rowReader reading from r, where every line must have
+// columns IDs, or as many as the first line if columns is 0.
+func newRowReader(r io.Reader, columns int) *rowReader {
+	return &rowReader{scanner: bufio.NewScanner(r), columns: columns}
+}
+
+// All returns an iterator over the location IDs on each line. The row is reused
+// for the next line so must be copied to be kept.
+//
+// Blank lines are skipped, iteration stops at the first bad line or read error
+// which is then reported by Err.
+func (r *rowReader) All() iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		for r.scanner.Scan() {
+			r.lineNo++
+
+			// ScanLines already drops the \r of a \r\n line ending
+			ok, err := r.parse(r.scanner.Bytes())
+			if err != nil {
+				r.err = err
+				return
+			}
+
+			if !ok {
+				// Blank line
+				continue
+			}
+
+			if !yield(r.row) {
+				return
+			}
+		}
+
+		if err := r.scanner.Err(); err != nil {
+			r.err = fmt.Errorf("could not read line %d: %w", r.lineNo+1, err)
+		}
+	}
+}
+
+// Err returns the first error encountered by All, or nil if the whole stream was
+// read successfully.
+func (r *rowReader) Err() error {
+	return r.err
+}
+
+// parse parses the location IDs on line into r.row, ok is false for a blank line
+// which has no IDs but isn't an error.
+func (r *rowReader) parse(line []byte) (ok bool, err error) {
+	// No line can have more fields than this, so splitFields never misses any
+	if want := len(line)/2 + 1; len(r.fields) < want {
+		r.fields = make([]field, want)
+	}
+
+	n := splitFields(line, r.fields)
+	if n == 0 {
+		return false, nil
+	}
+
+	if r.columns == 0 {
+		r.columns = n
+	}
+
+	if n != r.columns {
+		return false, columnCountError(line, r.lineNo, r.fields[:n], r.columns)
+	}
+
+	r.row = r.row[:0]
+	for i, f := range r.fields[:n] {
+		id, ok := parse.Int(line[f.start:f.end])
+		if !ok {
+			return false, &ParseError{
+				Line:   string(line),
+				Msg:    fmt.Sprintf("bad location ID %q in %s", line[f.start:f.end], columnName(i, n)),
+				LineNo: r.lineNo,
+				Col:    f.start + 1,
+				Len:    f.end - f.start,
+			}
+		}
+
+		r.row = append(r.row, id)
+	}
+
+	return true, nil
+}
+
+// columnName describes column i of n for error messages, two columns are the
+// puzzle's left and right lists.
+func columnName(i, n int) string {
+	if n == 2 {
+		return [...]string{"left list", "right list"}[i]
+	}
+
+	return fmt.Sprintf("column %d", i+1)
+}
+
+// columnCountError returns a ParseError for a line with the wrong number of columns,
+// pointing at the first extra ID or the end of the line if there are too few.
+func columnCountError(line []byte, lineNo int, fields []field, want int) *ParseError {
+	err := &ParseError{
+		Line:   string(line),
+		Msg:    fmt.Sprintf("expected %d location IDs, found %d", want, len(fields)),
+		LineNo: lineNo,
+	}
+
+	last := fields[len(fields)-1]
+	if len(fields) < want {
+		err.Col = last.end + 1
+		err.Len = 1
+	} else {
+		err.Col = fields[want].start + 1
+		err.Len = last.end - fields[want].start
+	}
+
+	return err
+}
+
+// Comparison is the total distance and similarity score between every pair of
+// columns, indexed [from][to].
+//
+// Both are symmetric: pairing sorted lists doesn't care which is which, and the
+// similarity score is the sum of each ID times its count in one column times its
+// count in the other. So [i][j] and [j][i] are always equal. Comparing a column with
+// itself isn't interesting so the diagonal is left as zero.
+type Comparison struct {
+	Distance   [][]int // Total distance between each pair of columns
+	Similarity [][]int // Similarity score of each pair of columns
+}
+
+// Pair identifies a pair of columns by their 0 based index, as the comparison is
+// symmetric From is always the lower of the two.
+type Pair struct {
+	From int // The first column
+	To   int // The second column
+}
+
+// Compare computes the total distance and similarity score for every pair of
+// columns, which must all be the same length. Each pair is only worked out once
+// and mirrored, as the comparison is symmetric.
+func Compare(columns [][]int) (Comparison, error) {
+	if len(columns) < 2 {
+		return Comparison{}, fmt.Errorf("need at least 2 columns to compare, got %d", len(columns))
+	}
+
+	n := len(columns)
+	c := Comparison{Distance: make([][]int, n), Similarity: make([][]int, n)}
+
+	for i := range columns {
+		c.Distance[i] = make([]int, n)
+		c.Similarity[i] = make([]int, n)
+	}
+
+	for i, from := range columns {
+		for j := i + 1; j < n; j++ {
+			to := columns[j]
+
+			distance, err := totalDistance(from, to, UnmatchedError, AlgorithmAuto)
+			if err != nil {
+				return Comparison{}, fmt.Errorf("columns %d and %d: %w", i+1, j+1, err)
+			}
+
+			similarity, err := similarityScore(from, to, AlgorithmAuto)
+			if err != nil {
+				return Comparison{}, fmt.Errorf("columns %d and %d: %w", i+1, j+1, err)
+			}
+
+			c.Distance[i][j], c.Distance[j][i] = distance, distance
+			c.Similarity[i][j], c.Similarity[j][i] = similarity, similarity
+		}
+	}
+
+	return c, nil
+}
+
+// MostSimilar returns the pair of columns with the highest similarity score, the
+// first in row order if there's a tie.
+func (c Comparison) MostSimilar() Pair {
+	return c.extreme(func(score, best int) bool { return score > best })
+}
+
+// LeastSimilar returns the pair of columns with the lowest similarity score, the
+// first in row order if there's a tie. With only two columns it's the same pair as
+// MostSimilar.
+func (c Comparison) LeastSimilar() Pair {
+	return c.extreme(func(score, best int) bool { return score < best })
+}
+
+// extreme returns the first pair of distinct columns whose similarity score beats
+// every other according to better.
+func (c Comparison) extreme(better func(score, best int) bool) Pair {
+	var best Pair
+	found := false
+	for i, row := range c.Similarity {
+		for j := i + 1; j < len(row); j++ {
+			score := row[j]
+
+			if !found || better(score, c.Similarity[best.From][best.To]) {
+				best = Pair{From: i, To: j}
+				found = true
+			}
+		}
+	}
+
+	return best
+}
+
+// WriteText writes the distance and similarity matrices as aligned tables, with
+// columns numbered from 1, followed by the most and least similar pairs.
+func (c Comparison) WriteText(w io.Writer) error {
+	tab := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	for _, matrix := range [...]struct {
+		title  string
+		values [][]int
+	}{
+		{title: "Total distance", values: c.Distance},
+		{title: "Similarity score", values: c.Similarity},
+	} {
+		fmt.Fprintf(tab, "%s\n\n", matrix.title)
+
+		fmt.Fprint(tab, "\t")
+		for j := range matrix.values {
+			fmt.Fprintf(tab, "%d\t", j+1)
+		}
+		fmt.Fprintln(tab)
+
+		for i, row := range matrix.values {
+			fmt.Fprintf(tab, "%d\t", i+1)
+			for j, value := range row {
+				if i == j {
+					fmt.Fprint(tab, "-\t")
+				} else {
+					fmt.Fprintf(tab, "%d\t", value)
+				}
+			}
+			fmt.Fprintln(tab)
+		}
+
+		fmt.Fprintln(tab)
+	}
+
+	most, least := c.MostSimilar(), c.LeastSimilar()
+	fmt.Fprintf(tab, "Most similar: %d and %d (%d)\n", most.From+1, most.To+1, c.Similarity[most.From][most.To])
+	fmt.Fprintf(tab, "Least similar: %d and %d (%d)\n", least.From+1, least.To+1, c.Similarity[least.From][least.To])
+
+	return tab.Flush()
+}
+
+// WriteCSV writes the comparison as CSV with a header and one row per ordered pair
+// of columns, numbered from 1. The most and least columns mark the most and least
+// similar pairs, in both directions, and can both be true for the same pair.
+//
+//	from,to,distance,similarity,most,least
+//	1,2,11,31,true,false
+func (c Comparison) WriteCSV(w io.Writer) error {
+	most, least := c.MostSimilar(), c.LeastSimilar()
+
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"from", "to", "distance", "similarity", "most", "least"}); err != nil {
+		return err
+	}
+
+	for i, row := range c.Similarity {
+		for j, similarity := range row {
+			if i == j {
+				continue
+			}
+
+			pair := Pair{From: min(i, j), To: max(i, j)}
+			record := []string{
+				strconv.Itoa(i + 1),
+				strconv.Itoa(j + 1),
+				strconv.Itoa(c.Distance[i][j]),
+				strconv.Itoa(similarity),
+				strconv.FormatBool(pair == most),
+				strconv.FormatBool(pair == least),
+			}
+
+			if err := out.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	out.Flush()
+
+	return out.Error()
+}
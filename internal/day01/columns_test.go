@@ -0,0 +1,172 @@
+package day01
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/FollowTheProcess/test"
+)
+
+func TestParseColumns(t *testing.T) {
+	tests := []struct {
+		name    string  // Name of the test case
+		input   string  // Raw input
+		want    [][]int // Expected columns
+		wantErr string  // Expected error message, empty for no error
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:  "single column",
+			input: "1\n2\n",
+			want:  [][]int{{1, 2}},
+		},
+		{
+			name:  "three columns",
+			input: "3   4\t1\n\n4 3  2\r\n",
+			want:  [][]int{{3, 4}, {4, 3}, {1, 2}},
+		},
+		{
+			name:    "too few",
+			input:   "1 2 3\n4 5\n",
+			wantErr: "line 2, column 4: expected 3 location IDs, found 2",
+		},
+		{
+			name:    "too many",
+			input:   "1 2\n4 5 6 7\n",
+			wantErr: "line 2, column 5: expected 2 location IDs, found 4",
+		},
+		{
+			name:    "bad ID",
+			input:   "1 2 3\n4 x 6\n",
+			wantErr: `line 2, column 3: bad location ID "x" in column 2`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseColumns(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				test.Err(t, err)
+				if !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Errorf("error %q did not start with %q", err.Error(), tt.wantErr)
+				}
+				return
+			}
+
+			test.Ok(t, err)
+			test.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	columns := [][]int{
+		{3, 4, 2, 1, 3, 3},
+		{4, 3, 5, 3, 9, 3},
+		{1, 2, 3, 4, 5, 6},
+	}
+
+	got, err := Compare(columns)
+	test.Ok(t, err)
+
+	// Columns 1 and 2 are the puzzle example
+	test.DeepEqual(t, got.Distance, [][]int{
+		{0, 11, 5},
+		{11, 0, 6},
+		{5, 6, 0},
+	})
+	test.DeepEqual(t, got.Similarity, [][]int{
+		{0, 31, 16},
+		{31, 0, 18},
+		{16, 18, 0},
+	})
+
+	test.Equal(t, got.MostSimilar(), Pair{From: 0, To: 1})
+	test.Equal(t, got.LeastSimilar(), Pair{From: 0, To: 2})
+
+	_, err = Compare(columns[:1])
+	test.Err(t, err)
+}
+
+func TestCompareSymmetric(t *testing.T) {
+	for seed := range uint64(10) {
+		left, right := randomLists(200, 0, 20, seed)
+		middle, _ := randomLists(200, 0, 20, seed+100)
+
+		got, err := Compare([][]int{left, middle, right})
+		test.Ok(t, err)
+
+		for i := range 3 {
+			for j := range 3 {
+				test.Equal(t, got.Distance[i][j], got.Distance[j][i])
+				test.Equal(t, got.Similarity[i][j], got.Similarity[j][i])
+			}
+		}
+	}
+}
+
+func TestComparisonWrite(t *testing.T) {
+	got, err := Compare([][]int{{3, 4, 2, 1, 3, 3}, {4, 3, 5, 3, 9, 3}, {1, 2, 3, 4, 5, 6}})
+	test.Ok(t, err)
+
+	text := &bytes.Buffer{}
+	test.Ok(t, got.WriteText(text))
+
+	wantText := `Total distance
+
+      1   2  3
+  1   -  11  5
+  2  11   -  6
+  3   5   6  -
+
+Similarity score
+
+      1   2   3
+  1   -  31  16
+  2  31   -  18
+  3  16  18   -
+
+Most similar: 1 and 2 (31)
+Least similar: 1 and 3 (16)
+`
+	test.Diff(t, text.String(), wantText)
+
+	csv := &bytes.Buffer{}
+	test.Ok(t, got.WriteCSV(csv))
+
+	wantCSV := `from,to,distance,similarity,most,least
+1,2,11,31,true,false
+1,3,5,16,false,true
+2,1,11,31,true,false
+2,3,6,18,false,false
+3,1,5,16,false,true
+3,2,6,18,false,false
+`
+	test.Diff(t, csv.String(), wantCSV)
+}
+
+func TestComparisonWriteTwoColumns(t *testing.T) {
+	got, err := Compare([][]int{{3, 4, 2, 1, 3, 3}, {4, 3, 5, 3, 9, 3}})
+	test.Ok(t, err)
+
+	// With only one pair it's both the most and least similar
+	test.Equal(t, got.MostSimilar(), got.LeastSimilar())
+
+	text := &bytes.Buffer{}
+	test.Ok(t, got.WriteText(text))
+	test.True(t, strings.Contains(text.String(), "Most similar: 1 and 2 (31)\nLeast similar: 1 and 2 (31)\n"))
+
+	csv := &bytes.Buffer{}
+	test.Ok(t, got.WriteCSV(csv))
+
+	wantCSV := `from,to,distance,similarity,most,least
+1,2,11,31,true,true
+2,1,11,31,true,true
+`
+	test.Diff(t, csv.String(), wantCSV)
+}
@@ -0,0 +1,154 @@
+package day01
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// maxCountingID is the exclusive upper bound on IDs for the counting fast path, it
+	// keeps the dense count arrays to a few MB. Real location IDs are 5 digits.
+	maxCountingID = 1 << 20
+
+	// sparseness is how many slots of the count arrays AlgorithmAuto will tolerate
+	// per ID before falling back to AlgorithmComparison. Zeroing and walking a mostly
+	// empty array costs more than sorting a short list: 1,000 5 digit IDs are
+	// several times faster to sort.
+	sparseness = 16
+)
+
+// Algorithm selects how the lists are sorted and counted.
+type Algorithm int
+
+const (
+	// AlgorithmAuto uses AlgorithmCounting when every ID is within its bounds
+	// and there are enough IDs to make it pay off, and AlgorithmComparison
+	// otherwise.
+	AlgorithmAuto Algorithm = iota
+
+	// AlgorithmComparison sorts with a comparison sort and counts with a hash
+	// map, it works for any IDs.
+	AlgorithmComparison
+
+	// AlgorithmCounting counts every ID into a dense array indexed by ID, which
+	// is a counting sort (a radix sort with a single, very wide, digit) and gives
+	// the counts needed for the similarity score in the same O(n) pass. It only
+	// works for IDs that are non-negative and less than 2^20.
+	AlgorithmCounting
+)
+
+// String implements [fmt.Stringer] for an Algorithm.
+func (a Algorithm) String() string {
+	switch a {
+	case AlgorithmAuto:
+		return "auto"
+	case AlgorithmComparison:
+		return "comparison"
+	case AlgorithmCounting:
+		return "counting"
+	default:
+		return fmt.Sprintf("Algorithm(%d)", int(a))
+	}
+}
+
+// choose resolves the algorithm to use for the given lists, returning the largest
+// ID if that's AlgorithmCounting.
+//
+// It is an error to ask for AlgorithmCounting for lists it can't handle.
+func (a Algorithm) choose(left, right []int) (Algorithm, int, error) {
+	switch a {
+	case AlgorithmComparison:
+		return AlgorithmComparison, 0, nil
+	case AlgorithmAuto, AlgorithmCounting:
+		bound, ok := countingBound(left, right)
+		if a == AlgorithmCounting && ok {
+			return AlgorithmCounting, bound, nil
+		}
+
+		if a == AlgorithmAuto {
+			if ok && bound/sparseness < len(left)+len(right) {
+				return AlgorithmCounting, bound, nil
+			}
+
+			return AlgorithmComparison, 0, nil
+		}
+
+		return 0, 0, fmt.Errorf("%s algorithm needs every location ID in [0, %d)", a, maxCountingID)
+	default:
+		return 0, 0, fmt.Errorf("unknown algorithm %d", int(a))
+	}
+}
+
+// countingBound returns the largest ID in either list, reporting whether every ID
+// is suitable for AlgorithmCounting.
+func countingBound(left, right []int) (int, bool) {
+	largest := 0
+	for _, list := range [...][]int{left, right} {
+		for _, id := range list {
+			if id < 0 || id >= maxCountingID {
+				return 0, false
+			}
+
+			largest = max(largest, id)
+		}
+	}
+
+	return largest, true
+}
+
+// denseCounts returns the number of times each ID appears in ids, indexed by ID.
+// Every ID must be in [0, bound].
+func denseCounts(ids []int, bound int) []int {
+	counts := make([]int, bound+1)
+	for _, id := range ids {
+		counts[id]++
+	}
+
+	return counts
+}
+
+// countingDistance is totalDistance for AlgorithmCounting.
+//
+// Walking both count arrays in ID order visits each list in sorted order, so the
+// n'th smallest IDs are paired up without ever building the sorted lists. Like
+// the comparison path, pairing stops when the shorter list runs out.
+func countingDistance(left, right []int, bound int) int {
+	leftCounts := denseCounts(left, bound)
+	rightCounts := denseCounts(right, bound)
+
+	sum := 0
+	i, j := 0, 0
+	for {
+		for i <= bound && leftCounts[i] == 0 {
+			i++
+		}
+
+		for j <= bound && rightCounts[j] == 0 {
+			j++
+		}
+
+		if i > bound || j > bound {
+			return sum
+		}
+
+		// The next n smallest IDs in each list pair up with each other
+		n := min(leftCounts[i], rightCounts[j])
+		sum += n * int(math.Abs(float64(i-j)))
+
+		leftCounts[i] -= n
+		rightCounts[j] -= n
+	}
+}
+
+// countingSimilarity is similarityScore for AlgorithmCounting, it looks up counts
+// in a dense array rather than a hash map.
+func countingSimilarity(left, right []int, bound int) int {
+	rightCounts := denseCounts(right, bound)
+
+	sum := 0
+	for _, id := range left {
+		sum += id * rightCounts[id]
+	}
+
+	return sum
+}
@@ -0,0 +1,198 @@
+package day01
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"testing"
+
+	"github.com/FollowTheProcess/test"
+)
+
+// randomLists returns two lists of n random location IDs in [lo, hi).
+func randomLists(n, lo, hi int, seed uint64) (left, right []int) {
+	r := rand.New(rand.NewPCG(seed, seed))
+	left = make([]int, n)
+	right = make([]int, n)
+	for i := range n {
+		left[i] = lo + r.IntN(hi-lo)
+		right[i] = lo + r.IntN(hi-lo)
+	}
+
+	return left, right
+}
+
+func TestAlgorithmChoose(t *testing.T) {
+	tests := []struct {
+		name      string    // Name of the test case
+		left      []int     // The left list
+		right     []int     // The right list
+		algorithm Algorithm // The algorithm asked for
+		want      Algorithm // The algorithm expected to be used
+		wantErr   bool      // Whether we want an error
+	}{
+		{
+			name:      "auto bounded",
+			left:      []int{3, 4, 2},
+			right:     []int{4, 3, 5},
+			algorithm: AlgorithmAuto,
+			want:      AlgorithmCounting,
+		},
+		{
+			name:      "auto sparse",
+			left:      []int{3, 4, 2},
+			right:     []int{4, 3, 5000},
+			algorithm: AlgorithmAuto,
+			want:      AlgorithmComparison,
+		},
+		{
+			name:      "auto negative",
+			left:      []int{3, -4, 2},
+			right:     []int{4, 3, 5},
+			algorithm: AlgorithmAuto,
+			want:      AlgorithmComparison,
+		},
+		{
+			name:      "auto too big",
+			left:      []int{3, 4, 2},
+			right:     []int{4, maxCountingID, 5},
+			algorithm: AlgorithmAuto,
+			want:      AlgorithmComparison,
+		},
+		{
+			name:      "comparison bounded",
+			left:      []int{3, 4, 2},
+			right:     []int{4, 3, 5},
+			algorithm: AlgorithmComparison,
+			want:      AlgorithmComparison,
+		},
+		{
+			name:      "counting bounded",
+			left:      []int{3, 4, 2},
+			right:     []int{4, 3, maxCountingID - 1},
+			algorithm: AlgorithmCounting,
+			want:      AlgorithmCounting,
+		},
+		{
+			name:      "counting negative",
+			left:      []int{-1},
+			right:     []int{4},
+			algorithm: AlgorithmCounting,
+			wantErr:   true,
+		},
+		{
+			name:      "counting too big",
+			left:      []int{1},
+			right:     []int{maxCountingID},
+			algorithm: AlgorithmCounting,
+			wantErr:   true,
+		},
+		{
+			name:      "unknown",
+			left:      []int{1},
+			right:     []int{1},
+			algorithm: Algorithm(42),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := tt.algorithm.choose(tt.left, tt.right)
+			test.WantErr(t, err, tt.wantErr)
+			test.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestCountingMatchesComparison(t *testing.T) {
+	tests := []struct {
+		name      string    // Name of the test case
+		unmatched Unmatched // The policy for leftover IDs
+		n         int       // Number of IDs in the left list
+		extra     int       // Number of extra IDs in the right list
+		hi        int       // Exclusive upper bound on IDs
+	}{
+		{name: "empty", n: 0, hi: 10},
+		{name: "lots of duplicates", n: 1000, hi: 10},
+		{name: "few duplicates", n: 1000, hi: 100_000},
+		{name: "largest allowed", n: 1000, hi: maxCountingID},
+		{name: "unmatched", n: 1000, extra: 17, hi: 1000, unmatched: UnmatchedIgnore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := range uint64(10) {
+				left, right := randomLists(tt.n+tt.extra, 0, tt.hi, seed)
+				left = left[:tt.n]
+
+				want, err := totalDistance(left, right, tt.unmatched, AlgorithmComparison)
+				test.Ok(t, err)
+
+				got, err := totalDistance(left, right, tt.unmatched, AlgorithmCounting)
+				test.Ok(t, err)
+				test.Equal(t, got, want)
+
+				want, err = similarityScore(left, right, AlgorithmComparison)
+				test.Ok(t, err)
+
+				got, err = similarityScore(left, right, AlgorithmCounting)
+				test.Ok(t, err)
+				test.Equal(t, got, want)
+			}
+		})
+	}
+}
+
+func TestSolutionAlgorithm(t *testing.T) {
+	for _, algorithm := range []Algorithm{AlgorithmAuto, AlgorithmComparison, AlgorithmCounting} {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			solution := &Solution{Algorithm: algorithm}
+			test.Ok(t, solution.Parse(input))
+
+			part1, err := solution.Part1()
+			test.Ok(t, err)
+			test.Equal(t, part1.String(), "2164381")
+
+			part2, err := solution.Part2()
+			test.Ok(t, err)
+			test.Equal(t, part2.String(), "20719933")
+		})
+	}
+
+	solution := &Solution{Algorithm: AlgorithmCounting}
+	test.Ok(t, solution.Parse("1   -1\n"))
+
+	_, err := solution.Part1()
+	test.Err(t, err)
+
+	_, err = solution.Part2()
+	test.Err(t, err)
+}
+
+// Set AOC_BENCH_HUGE to include 100M rows, which needs a few GB of memory.
+func BenchmarkAlgorithm(b *testing.B) {
+	sizes := []int{1_000, 1_000_000}
+	if os.Getenv("AOC_BENCH_HUGE") != "" {
+		sizes = append(sizes, 100_000_000)
+	}
+
+	for _, n := range sizes {
+		// Real location IDs are 5 digits
+		left, right := randomLists(n, 10000, 100_000, 1)
+
+		for _, algorithm := range []Algorithm{AlgorithmAuto, AlgorithmComparison, AlgorithmCounting} {
+			b.Run(fmt.Sprintf("%s/%d", algorithm, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					if _, err := totalDistance(left, right, UnmatchedError, algorithm); err != nil {
+						b.Fatal(err)
+					}
+					if _, err := similarityScore(left, right, algorithm); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -90,70 +90,168 @@ So, for these example lists, the similarity score at the end of this process is
 Once again consider your left and right lists. What is their similarity score?
 */
 
-package main
+package day01
 
 import (
-	"bufio"
 	_ "embed"
 	"fmt"
+	"io"
 	"math"
-	"os"
 	"slices"
-	"strconv"
 	"strings"
 
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
 	"github.com/FollowTheProcess/collections/counter"
-	"github.com/FollowTheProcess/msg"
 )
 
 //go:embed day01.txt
 var input string
 
-func main() {
-	if err := run(input); err != nil {
-		msg.Error("%v", err)
-		os.Exit(1)
+//go:embed answers.txt
+var answers string
+
+// Day returns the day 1 puzzle, ready to be registered with the runner.
+func Day() aoc.Day {
+	return aoc.Day{
+		Number:  1,
+		Input:   input,
+		Answers: answers,
+		New:     func() aoc.Solution { return &Solution{} },
 	}
 }
 
-func run(input string) error {
+// Solution is the solution to day 1, it implements [aoc.Solution].
+type Solution struct {
+	left      []int     // The left list of location IDs
+	right     []int     // The right list of location IDs
+	Unmatched Unmatched // What to do if the lists are different lengths, default error
+	Algorithm Algorithm // How to sort and count the lists, default automatic
+}
+
+// Parse parses the two lists of location IDs from the puzzle input.
+func (s *Solution) Parse(input string) error {
 	left, right, err := parseInput(input)
 	if err != nil {
 		return err
 	}
 
-	distance := totalDistance(left, right)
-	similarity := similarityScore(left, right)
-
-	fmt.Printf("Part 1: %d\n", distance)
-	fmt.Printf("Part2: %d\n", similarity)
+	s.left = left
+	s.right = right
 
 	return nil
 }
 
+// Part1 returns the total distance between the two lists.
+func (s *Solution) Part1() (aoc.Answer, error) {
+	distance, err := totalDistance(s.left, s.right, s.Unmatched, s.Algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	return aoc.Int(distance), nil
+}
+
+// Part2 returns the similarity score of the two lists.
+func (s *Solution) Part2() (aoc.Answer, error) {
+	similarity, err := similarityScore(s.left, s.right, s.Algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	return aoc.Int(similarity), nil
+}
+
+// Stream implements [aoc.Streamer], solving both parts in a single pass over r with
+// [StreamTotals] so the lists never have to fit in memory.
+func (s *Solution) Stream(r io.Reader) ([2]aoc.Answer, error) {
+	distance, similarity, err := StreamTotals(r)
+	if err != nil {
+		return [2]aoc.Answer{}, err
+	}
+
+	return [2]aoc.Answer{aoc.Int(distance), aoc.Int(similarity)}, nil
+}
+
+// Unmatched is the policy for location IDs left over when one list is longer than
+// the other, so that some IDs have nothing to pair up with.
+//
+// The puzzle promises lists of equal length so the default is to treat anything
+// else as an error.
+type Unmatched int
+
+const (
+	// UnmatchedError reports lists of different lengths as an error.
+	UnmatchedError Unmatched = iota
+
+	// UnmatchedIgnore pairs up as many IDs as possible and ignores the rest, which
+	// after sorting are the largest IDs of the longer list.
+	UnmatchedIgnore
+)
+
 // totalDistance calculates the total distance between the two lists, where
 // the distance is the sum of the differences between each element in the sorted
 // lists.
-func totalDistance(left, right []int) int {
-	// Sort each so we can pair elements up in order
-	slices.Sort(left)
-	slices.Sort(right)
+//
+// The lists are not modified. If they are different lengths, unmatched decides
+// what happens to the leftover IDs.
+func totalDistance(left, right []int, unmatched Unmatched, algorithm Algorithm) (int, error) {
+	if err := checkLengths(len(left), len(right), unmatched); err != nil {
+		return 0, err
+	}
+
+	algorithm, bound, err := algorithm.choose(left, right)
+	if err != nil {
+		return 0, err
+	}
+
+	if algorithm == AlgorithmCounting {
+		return countingDistance(left, right, bound), nil
+	}
+
+	// Sort copies so we can pair elements up in order without reordering
+	// the caller's lists
+	left = slices.Sorted(slices.Values(left))
+	right = slices.Sorted(slices.Values(right))
 
-	// The sum of all the diffs
+	// The sum of all the diffs, zip stops at the end of the shorter list
 	sum := 0
-	for index, leftValue := range left {
-		rightValue := right[index]
-		diff := math.Abs(float64(leftValue - rightValue))
+	for index := range min(len(left), len(right)) {
+		diff := math.Abs(float64(left[index] - right[index]))
 		sum += int(diff)
 	}
 
-	return sum
+	return sum, nil
+}
+
+// checkLengths applies the unmatched policy to lists of the given lengths.
+func checkLengths(left, right int, unmatched Unmatched) error {
+	if left == right {
+		return nil
+	}
+
+	switch unmatched {
+	case UnmatchedIgnore:
+		return nil
+	case UnmatchedError:
+		return fmt.Errorf("lists are different lengths: left has %d location IDs, right has %d", left, right)
+	default:
+		return fmt.Errorf("unknown unmatched policy %d", unmatched)
+	}
 }
 
 // similarityScore calculates the similarity score of the two lists by iterating through
 // the numbers in the left list, multiplying them by the number of times they occur in the
 // right list, and summing all this together.
-func similarityScore(left, right []int) int {
+func similarityScore(left, right []int, algorithm Algorithm) (int, error) {
+	algorithm, bound, err := algorithm.choose(left, right)
+	if err != nil {
+		return 0, err
+	}
+
+	if algorithm == AlgorithmCounting {
+		return countingSimilarity(left, right, bound), nil
+	}
+
 	// Counts of everything in the right hand list
 	counts := counter.From(right)
 
@@ -165,38 +263,16 @@ func similarityScore(left, right []int) int {
 		sum += similarity
 	}
 
-	return sum
+	return sum, nil
 }
 
 // parseInput parses the raw input text into two lists of integers representing
-// the left list and the right list.
+// the left list and the right list, it's [ParseColumns] insisting on exactly two.
 func parseInput(input string) (left, right []int, err error) {
-	input = strings.TrimSpace(input)
-	scanner := bufio.NewScanner(strings.NewReader(input))
-
-	lineNo := 1
-
-	for scanner.Scan() {
-		lineNo++
-		line := scanner.Text()
-		leftRaw, rightRaw, ok := strings.Cut(line, "   ")
-		if !ok {
-			return nil, nil, fmt.Errorf("Bad line %s", line)
-		}
-
-		leftInt, err := strconv.Atoi(leftRaw)
-		if err != nil {
-			return nil, nil, fmt.Errorf("Bad numeric value in left list %s on line %d: %w", leftRaw, lineNo, err)
-		}
-
-		rightInt, err := strconv.Atoi(rightRaw)
-		if err != nil {
-			return nil, nil, fmt.Errorf("Bad numeric value in right list %s on line %d: %w", rightRaw, lineNo, err)
-		}
-
-		left = append(left, leftInt)
-		right = append(right, rightInt)
+	columns, err := parseColumns(strings.NewReader(input), 2)
+	if err != nil || columns == nil {
+		return nil, nil, err
 	}
 
-	return left, right, nil
+	return columns[0], columns[1], nil
 }
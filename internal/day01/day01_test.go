@@ -0,0 +1,292 @@
+package day01
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/test"
+)
+
+const testInput = `
+3   4
+4   3
+2   5
+1   3
+3   9
+3   3
+`
+
+func TestParseInput(t *testing.T) {
+	left, right, err := parseInput(testInput)
+	test.Ok(t, err)
+
+	wantLeft := []int{3, 4, 2, 1, 3, 3}
+	wantRight := []int{4, 3, 5, 3, 9, 3}
+
+	test.EqualFunc(t, left, wantLeft, slices.Equal)
+	test.EqualFunc(t, right, wantRight, slices.Equal)
+}
+
+func TestParseInputWhitespace(t *testing.T) {
+	tests := []struct {
+		name  string // Name of the test case
+		input string // The raw input
+	}{
+		{name: "three spaces", input: "3   4\n4   3\n"},
+		{name: "one space", input: "3 4\n4 3\n"},
+		{name: "tabs", input: "3\t4\n4\t\t3\n"},
+		{name: "mixed", input: "  3 \t 4  \n\t4   3\t\n"},
+		{name: "crlf", input: "3   4\r\n4   3\r\n"},
+		{name: "blank lines", input: "\n\n3   4\n\n   \n4   3\n\n"},
+		{name: "no trailing newline", input: "3   4\n4   3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, right, err := parseInput(tt.input)
+			test.Ok(t, err)
+			test.EqualFunc(t, left, []int{3, 4}, slices.Equal)
+			test.EqualFunc(t, right, []int{4, 3}, slices.Equal)
+		})
+	}
+}
+
+func TestParseInputErrors(t *testing.T) {
+	tests := []struct {
+		name  string     // Name of the test case
+		input string     // The raw input
+		want  ParseError // The expected error
+	}{
+		{
+			name:  "bad right",
+			input: "1   2\n3   4x\n",
+			want:  ParseError{Line: "3   4x", Msg: `bad location ID "4x" in right list`, LineNo: 2, Col: 5, Len: 2},
+		},
+		{
+			name:  "bad left",
+			input: "\n\n  abc 2\n",
+			want:  ParseError{Line: "  abc 2", Msg: `bad location ID "abc" in left list`, LineNo: 3, Col: 3, Len: 3},
+		},
+		{
+			name:  "only one",
+			input: "1   2\n3  \n",
+			want:  ParseError{Line: "3  ", Msg: "expected 2 location IDs, found 1", LineNo: 2, Col: 2, Len: 1},
+		},
+		{
+			name:  "too many",
+			input: "1   2   3 4\n",
+			want:  ParseError{Line: "1   2   3 4", Msg: "expected 2 location IDs, found 4", LineNo: 1, Col: 9, Len: 3},
+		},
+		{
+			name:  "overflow",
+			input: "1   99999999999999999999\r\n",
+			want:  ParseError{Line: "1   99999999999999999999", Msg: `bad location ID "99999999999999999999" in right list`, LineNo: 1, Col: 5, Len: 20},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseInput(tt.input)
+			test.Err(t, err)
+
+			var parseErr *ParseError
+			test.True(t, errors.As(err, &parseErr))
+			test.Equal(t, *parseErr, tt.want)
+		})
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	err := &ParseError{Line: "3   4x", Msg: `bad location ID "4x" in right list`, LineNo: 2, Col: 5, Len: 2}
+	want := "line 2, column 5: bad location ID \"4x\" in right list\n\n  2 | 3   4x\n    |     ^^"
+	test.Equal(t, err.Error(), want)
+
+	tabs := &ParseError{Line: "3\t\tx", Msg: "nope", LineNo: 10, Col: 4, Len: 1}
+	want = "line 10, column 4: nope\n\n  10 | 3\t\tx\n     |  \t\t^"
+	test.Equal(t, tabs.Error(), want)
+}
+
+func TestTotalDifference(t *testing.T) {
+	left := []int{3, 4, 2, 1, 3, 3}
+	right := []int{4, 3, 5, 3, 9, 3}
+
+	want := 11
+
+	for _, algorithm := range []Algorithm{AlgorithmComparison, AlgorithmCounting} {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			got, err := totalDistance(left, right, UnmatchedError, algorithm)
+			test.Ok(t, err)
+			test.Equal(t, got, want)
+
+			// The caller's lists must be left as they were
+			test.EqualFunc(t, left, []int{3, 4, 2, 1, 3, 3}, slices.Equal)
+			test.EqualFunc(t, right, []int{4, 3, 5, 3, 9, 3}, slices.Equal)
+		})
+	}
+}
+
+func TestTotalDifferenceUnmatched(t *testing.T) {
+	tests := []struct {
+		name      string    // Name of the test case
+		left      []int     // The left list
+		right     []int     // The right list
+		unmatched Unmatched // The policy for leftover IDs
+		want      int       // Expected distance
+		wantErr   bool      // Whether we want an error
+	}{
+		{
+			name:      "longer right error",
+			left:      []int{1, 2},
+			right:     []int{1, 2, 3},
+			unmatched: UnmatchedError,
+			wantErr:   true,
+		},
+		{
+			name:      "longer left error",
+			left:      []int{1, 2, 3},
+			right:     []int{1},
+			unmatched: UnmatchedError,
+			wantErr:   true,
+		},
+		{
+			name:      "longer right ignore",
+			left:      []int{5, 1},
+			right:     []int{100, 2, 3},
+			unmatched: UnmatchedIgnore,
+			want:      3, // 1-2 and 5-3, 100 has no partner
+		},
+		{
+			name:      "empty left ignore",
+			left:      nil,
+			right:     []int{1, 2, 3},
+			unmatched: UnmatchedIgnore,
+			want:      0,
+		},
+		{
+			name:      "unknown policy",
+			left:      []int{1},
+			right:     []int{1, 2},
+			unmatched: Unmatched(42),
+			wantErr:   true,
+		},
+		{
+			name:      "equal lengths ignore",
+			left:      []int{3, 4, 2, 1, 3, 3},
+			right:     []int{4, 3, 5, 3, 9, 3},
+			unmatched: UnmatchedIgnore,
+			want:      11,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := totalDistance(tt.left, tt.right, tt.unmatched, AlgorithmAuto)
+			test.WantErr(t, err, tt.wantErr)
+			test.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestSimilarityUnaffectedByDistance(t *testing.T) {
+	left := []int{3, 4, 2, 1, 3, 3}
+	right := []int{4, 3, 5, 3, 9, 3}
+
+	for _, algorithm := range []Algorithm{AlgorithmComparison, AlgorithmCounting} {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			before, err := similarityScore(left, right, algorithm)
+			test.Ok(t, err)
+
+			_, err = totalDistance(left, right, UnmatchedError, algorithm)
+			test.Ok(t, err)
+
+			after, err := similarityScore(left, right, algorithm)
+			test.Ok(t, err)
+			test.Equal(t, after, before)
+		})
+	}
+}
+
+func TestSimilarityScore(t *testing.T) {
+	left := []int{3, 4, 2, 1, 3, 3}
+	right := []int{4, 3, 5, 3, 9, 3}
+
+	want := 31
+
+	got, err := similarityScore(left, right, AlgorithmAuto)
+	test.Ok(t, err)
+	test.Equal(t, got, want)
+}
+
+func TestSolutionUnmatched(t *testing.T) {
+	solution := &Solution{}
+	solution.left = []int{1, 2, 3}
+	solution.right = []int{1, 2}
+
+	_, err := solution.Part1()
+	test.Err(t, err)
+
+	solution.Unmatched = UnmatchedIgnore
+
+	got, err := solution.Part1()
+	test.Ok(t, err)
+	test.Equal(t, got, aoc.Answer("0"))
+}
+
+func TestSolution(t *testing.T) {
+	solution := &Solution{}
+	test.Ok(t, solution.Parse(testInput))
+
+	part1, err := solution.Part1()
+	test.Ok(t, err)
+	test.Equal(t, part1, aoc.Answer("11")) // Wrong answer for part 1 example
+
+	part2, err := solution.Part2()
+	test.Ok(t, err)
+	test.Equal(t, part2, aoc.Answer("31")) // Wrong answer for part 2 example
+}
+
+func TestExplain(t *testing.T) {
+	solution := &Solution{}
+	test.Ok(t, solution.Parse(testInput))
+
+	explained, err := solution.Explain()
+	test.Ok(t, err)
+
+	got, ok := explained.(explanation)
+	test.True(t, ok)
+
+	// Straight from the puzzle walkthrough
+	wantPairs := []pairStep{
+		{Left: 1, Right: 3, Distance: 2},
+		{Left: 2, Right: 3, Distance: 1},
+		{Left: 3, Right: 3, Distance: 0},
+		{Left: 3, Right: 4, Distance: 1},
+		{Left: 3, Right: 5, Distance: 2},
+		{Left: 4, Right: 9, Distance: 5},
+	}
+
+	wantSimilarity := []similarityStep{
+		{Left: 3, Occurrences: 3, Contribution: 9},
+		{Left: 4, Occurrences: 1, Contribution: 4},
+		{Left: 2, Occurrences: 0, Contribution: 0},
+		{Left: 1, Occurrences: 0, Contribution: 0},
+		{Left: 3, Occurrences: 3, Contribution: 9},
+		{Left: 3, Occurrences: 3, Contribution: 9},
+	}
+
+	test.Diff(t, got.Pairs, wantPairs)
+	test.Diff(t, got.Similarity, wantSimilarity)
+	test.Equal(t, got.TotalDistance, 11)
+	test.Equal(t, got.SimilarityScore, 31)
+
+	text := got.String()
+	test.True(t, strings.Contains(text, "Total distance: 11"))
+	test.True(t, strings.Contains(text, "Similarity score: 31"))
+
+	solution.right = solution.right[1:]
+	_, err = solution.Explain()
+	test.Err(t, err) // Mismatched lists with the default policy
+}
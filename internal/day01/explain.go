@@ -0,0 +1,102 @@
+package day01
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/collections/counter"
+)
+
+// explanation walks through both parts the way the puzzle text does.
+type explanation struct {
+	Pairs           []pairStep       `json:"pairs"`           // Each sorted pair and its distance
+	Similarity      []similarityStep `json:"similarity"`      // Each left ID's contribution to the similarity score
+	TotalDistance   int              `json:"totalDistance"`   // The part 1 answer
+	SimilarityScore int              `json:"similarityScore"` // The part 2 answer
+}
+
+// pairStep is the n'th smallest ID of each list paired together.
+type pairStep struct {
+	Left     int `json:"left"`     // The ID from the left list
+	Right    int `json:"right"`    // The ID from the right list
+	Distance int `json:"distance"` // How far apart they are
+}
+
+// similarityStep is one ID from the left list and how it adds to the similarity score.
+type similarityStep struct {
+	Left         int `json:"left"`         // The ID from the left list
+	Occurrences  int `json:"occurrences"`  // How many times it appears in the right list
+	Contribution int `json:"contribution"` // Left multiplied by Occurrences
+}
+
+// Explain walks through both parts step by step, it implements [aoc.Explainer].
+func (s *Solution) Explain() (aoc.Explanation, error) {
+	return explain(s.left, s.right, s.Unmatched)
+}
+
+// explain builds the explanation of the distance and similarity of the two lists.
+func explain(left, right []int, unmatched Unmatched) (explanation, error) {
+	if err := checkLengths(len(left), len(right), unmatched); err != nil {
+		return explanation{}, err
+	}
+
+	sortedLeft := slices.Sorted(slices.Values(left))
+	sortedRight := slices.Sorted(slices.Values(right))
+
+	var e explanation
+	for index := range min(len(sortedLeft), len(sortedRight)) {
+		step := pairStep{
+			Left:     sortedLeft[index],
+			Right:    sortedRight[index],
+			Distance: int(math.Abs(float64(sortedLeft[index] - sortedRight[index]))),
+		}
+		e.Pairs = append(e.Pairs, step)
+		e.TotalDistance += step.Distance
+	}
+
+	// The puzzle goes through the left list in its original order for part 2
+	counts := counter.From(right)
+	for _, leftValue := range left {
+		step := similarityStep{
+			Left:         leftValue,
+			Occurrences:  counts.Count(leftValue),
+			Contribution: leftValue * counts.Count(leftValue),
+		}
+		e.Similarity = append(e.Similarity, step)
+		e.SimilarityScore += step.Contribution
+	}
+
+	return e, nil
+}
+
+// String renders the explanation as a pair of text tables.
+func (e explanation) String() string {
+	s := &strings.Builder{}
+
+	// Lines without cells split the tables into separately aligned blocks
+	tab := tabwriter.NewWriter(s, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprint(tab, "Part 1: pair up the lists smallest to largest\n\n")
+	fmt.Fprintln(tab, "LEFT\tRIGHT\tDISTANCE\t")
+	for _, step := range e.Pairs {
+		fmt.Fprintf(tab, "%d\t%d\t%d\t\n", step.Left, step.Right, step.Distance)
+	}
+
+	fmt.Fprintf(tab, "\nTotal distance: %d\n\n", e.TotalDistance)
+
+	fmt.Fprint(tab, "Part 2: count each left ID's appearances in the right list\n\n")
+	fmt.Fprintln(tab, "LEFT\tOCCURRENCES\tCONTRIBUTION\t")
+	for _, step := range e.Similarity {
+		fmt.Fprintf(tab, "%d\t%d\t%d\t\n", step.Left, step.Occurrences, step.Contribution)
+	}
+
+	fmt.Fprintf(tab, "\nSimilarity score: %d\n", e.SimilarityScore)
+
+	tab.Flush() //nolint:errcheck // Writing to a strings.Builder never fails
+
+	return s.String()
+}
@@ -0,0 +1,125 @@
+package day01
+
+import (
+	"fmt"
+
+	"github.com/FollowTheProcess/collections/counter"
+)
+
+// Tracker keeps the total distance and similarity score of two lists up to date
+// as location IDs are added to and removed from them one at a time, so a live
+// stream can be reconciled without ever re-sorting the lists.
+//
+// The similarity score is maintained as IDs come and go, so reading it is O(1).
+// Each list is also kept in sorted order in an order statistics tree, so the k'th
+// pair is found in O(log n). The total distance isn't maintained incrementally, one
+// change can shift every pair, see Distance for its cost.
+type Tracker struct {
+	leftCounts  *counter.Counter[int] // Counts of each ID in the left list
+	rightCounts *counter.Counter[int] // Counts of each ID in the right list
+	left        orderedMultiset       // The left list in ascending order
+	right       orderedMultiset       // The right list in ascending order
+	similarity  int                   // The current similarity score
+	distance    int                   // The total distance, if distanceOK
+	distanceOK  bool                  // Whether distance is up to date
+}
+
+// NewTracker returns a Tracker with both lists empty.
+func NewTracker() *Tracker {
+	return &Tracker{leftCounts: counter.New[int](), rightCounts: counter.New[int]()}
+}
+
+// AddLeft adds id to the left list.
+func (t *Tracker) AddLeft(id int) {
+	t.leftCounts.Add(id)
+	t.left.Add(id)
+	t.similarity += id * t.rightCounts.Count(id)
+	t.distanceOK = false
+}
+
+// AddRight adds id to the right list.
+func (t *Tracker) AddRight(id int) {
+	t.rightCounts.Add(id)
+	t.right.Add(id)
+	t.similarity += id * t.leftCounts.Count(id)
+	t.distanceOK = false
+}
+
+// RemoveLeft removes one occurrence of id from the left list, it is an error if
+// the list doesn't contain it.
+func (t *Tracker) RemoveLeft(id int) error {
+	if !t.left.Remove(id) {
+		return fmt.Errorf("location ID %d is not in the left list", id)
+	}
+
+	t.leftCounts.Sub(id)
+	t.similarity -= id * t.rightCounts.Count(id)
+	t.distanceOK = false
+
+	return nil
+}
+
+// RemoveRight removes one occurrence of id from the right list, it is an error if
+// the list doesn't contain it.
+func (t *Tracker) RemoveRight(id int) error {
+	if !t.right.Remove(id) {
+		return fmt.Errorf("location ID %d is not in the right list", id)
+	}
+
+	t.rightCounts.Sub(id)
+	t.similarity -= id * t.leftCounts.Count(id)
+	t.distanceOK = false
+
+	return nil
+}
+
+// Len returns the current length of the left and right lists.
+func (t *Tracker) Len() (left, right int) {
+	return t.left.Len(), t.right.Len()
+}
+
+// Pair returns the k'th pair of IDs (counting from 0) when both lists are sorted,
+// ok is false if either list is too short to have one.
+func (t *Tracker) Pair(k int) (left, right int, ok bool) {
+	if k < 0 || k >= min(t.left.Len(), t.right.Len()) {
+		return 0, 0, false
+	}
+
+	return t.left.Select(k), t.right.Select(k), true
+}
+
+// Similarity returns the current similarity score, the equivalent of similarityScore.
+func (t *Tracker) Similarity() int {
+	return t.similarity
+}
+
+// Distance returns the current total distance, the equivalent of totalDistance.
+// If the lists are different lengths, unmatched decides what happens to the
+// leftover IDs.
+//
+// The first call after the lists change costs O(distinct IDs) time and memory, as
+// it walks both lists in order pairing them up. The result is cached so calls with
+// no change in between are O(1).
+func (t *Tracker) Distance(unmatched Unmatched) (int, error) {
+	if err := checkLengths(t.left.Len(), t.right.Len(), unmatched); err != nil {
+		return 0, err
+	}
+
+	if !t.distanceOK {
+		t.distance = pairedDistance(countsOf(&t.left), countsOf(&t.right))
+		t.distanceOK = true
+	}
+
+	return t.distance, nil
+}
+
+// countsOf returns every distinct ID in the multiset with its count, in ascending
+// order of ID.
+func countsOf(m *orderedMultiset) []counter.Pair[int] {
+	var pairs []counter.Pair[int]
+	for id, count := range m.All() {
+		pairs = append(pairs, counter.Pair[int]{Item: id, Count: count})
+	}
+
+	return pairs
+}
@@ -0,0 +1,105 @@
+package day01
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/FollowTheProcess/test"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker()
+
+	distance, err := tracker.Distance(UnmatchedError)
+	test.Ok(t, err)
+	test.Equal(t, distance, 0)
+	test.Equal(t, tracker.Similarity(), 0)
+
+	for _, id := range []int{3, 4, 2, 1, 3, 3} {
+		tracker.AddLeft(id)
+	}
+
+	_, err = tracker.Distance(UnmatchedError)
+	test.Err(t, err) // Right list is still empty
+
+	for _, id := range []int{4, 3, 5, 3, 9, 3} {
+		tracker.AddRight(id)
+	}
+
+	distance, err = tracker.Distance(UnmatchedError)
+	test.Ok(t, err)
+	test.Equal(t, distance, 11)
+	test.Equal(t, tracker.Similarity(), 31)
+
+	left, right, ok := tracker.Pair(5)
+	test.True(t, ok)
+	test.Equal(t, left, 4)
+	test.Equal(t, right, 9)
+
+	_, _, ok = tracker.Pair(6)
+	test.False(t, ok)
+
+	// Take the 9 away, 4 now pairs with 5 and the 3s are unaffected
+	test.Ok(t, tracker.RemoveRight(9))
+	test.Err(t, tracker.RemoveRight(9))
+	test.Err(t, tracker.RemoveLeft(9))
+
+	leftLen, rightLen := tracker.Len()
+	test.Equal(t, leftLen, 6)
+	test.Equal(t, rightLen, 5)
+
+	distance, err = tracker.Distance(UnmatchedIgnore)
+	test.Ok(t, err)
+	test.Equal(t, distance, 6)
+	test.Equal(t, tracker.Similarity(), 31)
+
+	test.Ok(t, tracker.RemoveLeft(3))
+	test.Equal(t, tracker.Similarity(), 22)
+}
+
+func TestTrackerMatchesBatch(t *testing.T) {
+	for seed := range uint64(20) {
+		r := rand.New(rand.NewPCG(seed, seed))
+		tracker := NewTracker()
+
+		// The lists as the batch functions see them
+		var left, right []int
+
+		for range 1000 {
+			id := r.IntN(30)
+			list := &left
+			add, remove := tracker.AddLeft, tracker.RemoveLeft
+			if r.IntN(2) == 0 {
+				list = &right
+				add, remove = tracker.AddRight, tracker.RemoveRight
+			}
+
+			if r.IntN(4) == 0 {
+				i := slices.Index(*list, id)
+				err := remove(id)
+				test.WantErr(t, err, i == -1)
+				if i != -1 {
+					*list = slices.Delete(*list, i, i+1)
+				}
+			} else {
+				add(id)
+				*list = append(*list, id)
+			}
+
+			want, err := totalDistance(left, right, UnmatchedIgnore, AlgorithmComparison)
+			test.Ok(t, err)
+
+			got, err := tracker.Distance(UnmatchedIgnore)
+			test.Ok(t, err)
+			test.Equal(t, got, want)
+
+			want, err = similarityScore(left, right, AlgorithmComparison)
+			test.Ok(t, err)
+			test.Equal(t, tracker.Similarity(), want)
+
+			_, err = tracker.Distance(UnmatchedError)
+			test.WantErr(t, err, len(left) != len(right))
+		}
+	}
+}
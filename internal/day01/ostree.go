@@ -0,0 +1,195 @@
+package day01
+
+import (
+	"iter"
+	"math/rand/v2"
+)
+
+// orderedMultiset is a multiset of location IDs kept in ascending order.
+//
+// It's an order statistics tree: a treap (a binary search tree kept balanced in
+// expectation by giving every node a random heap priority) where each node holds
+// one distinct ID, how many times it appears, and the total count of its subtree.
+// Adding and removing IDs, and finding the k'th smallest, are O(log n).
+//
+// The zero value is an empty multiset ready to use.
+type orderedMultiset struct {
+	root *treapNode // Root of the tree, nil when empty
+}
+
+// treapNode is a single distinct ID in an orderedMultiset.
+type treapNode struct {
+	left     *treapNode // Subtree of smaller IDs
+	right    *treapNode // Subtree of larger IDs
+	id       int        // The location ID
+	count    int        // How many times id appears
+	size     int        // Total count of every ID in this subtree
+	priority uint64     // Random heap priority, parents are never lower than children
+}
+
+// Len returns the number of IDs in the multiset, including duplicates.
+func (m *orderedMultiset) Len() int {
+	return m.root.total()
+}
+
+// Add adds one occurrence of id.
+func (m *orderedMultiset) Add(id int) {
+	m.update(id, func(node *treapNode) *treapNode {
+		if node == nil {
+			return &treapNode{id: id, count: 1, size: 1, priority: rand.Uint64()}
+		}
+
+		node.count++
+		return node
+	})
+}
+
+// Remove removes one occurrence of id, reporting whether there was one to remove.
+func (m *orderedMultiset) Remove(id int) bool {
+	removed := false
+	m.update(id, func(node *treapNode) *treapNode {
+		if node == nil {
+			return nil
+		}
+
+		removed = true
+		node.count--
+		if node.count == 0 {
+			return nil
+		}
+
+		return node
+	})
+
+	return removed
+}
+
+// Select returns the k'th smallest ID, counting from 0 and including duplicates.
+// k must be in [0, Len()).
+func (m *orderedMultiset) Select(k int) int {
+	node := m.root
+	for {
+		smaller := node.left.total()
+		switch {
+		case k < smaller:
+			node = node.left
+		case k < smaller+node.count:
+			return node.id
+		default:
+			k -= smaller + node.count
+			node = node.right
+		}
+	}
+}
+
+// All returns an iterator over each distinct ID and its count, in ascending
+// order of ID.
+func (m *orderedMultiset) All() iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		m.root.walk(yield)
+	}
+}
+
+// update isolates the node for id (nil if id isn't present), replaces it with
+// whatever fn returns and stitches the tree back together.
+func (m *orderedMultiset) update(id int, fn func(node *treapNode) *treapNode) {
+	smaller, rest := split(m.root, id)
+
+	// rest holds id at its smallest, so if present it's found by going left
+	var node *treapNode
+	if rest != nil {
+		if lowest := rest.lowest(); lowest.id == id {
+			node = lowest
+			rest = removeLowest(rest)
+			node.left, node.right = nil, nil
+		}
+	}
+
+	node = fn(node)
+	if node != nil {
+		node.fix()
+	}
+
+	m.root = merge(merge(smaller, node), rest)
+}
+
+// walk calls yield on every node of the subtree in order, stopping early if
+// yield returns false.
+func (n *treapNode) walk(yield func(id, count int) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	return n.left.walk(yield) && yield(n.id, n.count) && n.right.walk(yield)
+}
+
+// total returns the total count of the subtree rooted at n, which may be nil.
+func (n *treapNode) total() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+// fix recalculates n's size from its count and children.
+func (n *treapNode) fix() {
+	n.size = n.left.total() + n.count + n.right.total()
+}
+
+// lowest returns the node with the smallest ID in the subtree.
+func (n *treapNode) lowest() *treapNode {
+	for n.left != nil {
+		n = n.left
+	}
+
+	return n
+}
+
+// removeLowest returns the subtree with its smallest node removed.
+func removeLowest(n *treapNode) *treapNode {
+	if n.left == nil {
+		return n.right
+	}
+
+	n.left = removeLowest(n.left)
+	n.fix()
+
+	return n
+}
+
+// split splits the subtree into the IDs smaller than id and the rest.
+func split(n *treapNode, id int) (smaller, rest *treapNode) {
+	if n == nil {
+		return nil, nil
+	}
+
+	if n.id < id {
+		n.right, rest = split(n.right, id)
+		n.fix()
+		return n, rest
+	}
+
+	smaller, n.left = split(n.left, id)
+	n.fix()
+
+	return smaller, n
+}
+
+// merge joins two subtrees where every ID in left is smaller than every ID in right.
+func merge(left, right *treapNode) *treapNode {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case left.priority > right.priority:
+		left.right = merge(left.right, right)
+		left.fix()
+		return left
+	default:
+		right.left = merge(left, right.left)
+		right.fix()
+		return right
+	}
+}
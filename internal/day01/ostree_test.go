@@ -0,0 +1,81 @@
+package day01
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/FollowTheProcess/test"
+)
+
+func TestOrderedMultiset(t *testing.T) {
+	var m orderedMultiset
+	test.Equal(t, m.Len(), 0)
+	test.False(t, m.Remove(1))
+
+	for _, id := range []int{3, 4, 2, 1, 3, 3} {
+		m.Add(id)
+	}
+
+	test.Equal(t, m.Len(), 6)
+	test.Equal(t, m.Select(0), 1)
+	test.Equal(t, m.Select(3), 3)
+	test.Equal(t, m.Select(5), 4)
+
+	var distinct, counts []int
+	for id, count := range m.All() {
+		distinct = append(distinct, id)
+		counts = append(counts, count)
+	}
+
+	test.EqualFunc(t, distinct, []int{1, 2, 3, 4}, slices.Equal)
+	test.EqualFunc(t, counts, []int{1, 1, 3, 1}, slices.Equal)
+
+	test.True(t, m.Remove(3))
+	test.False(t, m.Remove(5))
+	test.Equal(t, m.Len(), 5)
+	test.Equal(t, m.Select(3), 3)
+	test.Equal(t, m.Select(4), 4)
+}
+
+func TestOrderedMultisetMatchesSortedSlice(t *testing.T) {
+	for seed := range uint64(20) {
+		r := rand.New(rand.NewPCG(seed, seed))
+
+		var (
+			m    orderedMultiset
+			want []int // Sorted reference copy of the multiset
+		)
+
+		for range 2000 {
+			id := r.IntN(50) - 10
+			if r.IntN(3) == 0 {
+				i, found := slices.BinarySearch(want, id)
+				test.Equal(t, m.Remove(id), found)
+				if found {
+					want = slices.Delete(want, i, i+1)
+				}
+			} else {
+				m.Add(id)
+				i, _ := slices.BinarySearch(want, id)
+				want = slices.Insert(want, i, id)
+			}
+
+			test.Equal(t, m.Len(), len(want))
+
+			if len(want) > 0 {
+				k := r.IntN(len(want))
+				test.Equal(t, m.Select(k), want[k])
+			}
+		}
+
+		var got []int
+		for id, count := range m.All() {
+			for range count {
+				got = append(got, id)
+			}
+		}
+
+		test.EqualFunc(t, got, want, slices.Equal)
+	}
+}
@@ -0,0 +1,246 @@
+package day01
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/FollowTheProcess/aoc2024/internal/parse"
+	"github.com/FollowTheProcess/collections/counter"
+)
+
+// pairReader reads pairs of location IDs from a stream one line at a time, so lists
+// far too big to hold in memory can still be processed. It's a rowReader that
+// insists on two columns.
+//
+// It works like a [bufio.Scanner], iterate with All and then check Err.
+type pairReader struct {
+	rows *rowReader // Reads each line's left and right ID
+}
+
+// newPairReader returns a pairReader reading from r.
+func newPairReader(r io.Reader) *pairReader {
+	return &pairReader{rows: newRowReader(r, 2)}
+}
+
+// All returns an iterator over the left and right location ID on each line.
+//
+// Blank lines are skipped, iteration stops at the first bad line or read error
+// which is then reported by Err.
+func (p *pairReader) All() iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for row := range p.rows.All() {
+			if !yield(row[0], row[1]) {
+				return
+			}
+		}
+	}
+}
+
+// Err returns the first error encountered by All, or nil if the whole stream was
+// read successfully.
+func (p *pairReader) Err() error {
+	return p.rows.Err()
+}
+
+// Pairs returns an iterator over the left and right location IDs on each line read
+// from r, one line at a time, so lists far too big to hold in memory can still be
+// processed.
+//
+// Lines are parsed the same way as the puzzle input and blank lines are skipped.
+// Iteration stops after yielding the first error, from either a bad line or r itself.
+func Pairs(r io.Reader) iter.Seq2[[2]int, error] {
+	return func(yield func([2]int, error) bool) {
+		pairs := newPairReader(r)
+		for left, right := range pairs.All() {
+			if !yield([2]int{left, right}, nil) {
+				return
+			}
+		}
+
+		if err := pairs.Err(); err != nil {
+			yield([2]int{}, err)
+		}
+	}
+}
+
+// ParseError is a problem with a specific line of the puzzle input.
+type ParseError struct {
+	Line   string // The offending line
+	Msg    string // What is wrong with it
+	LineNo int    // The 1 based line number
+	Col    int    // The 1 based byte offset in the line where the problem starts
+	Len    int    // How many bytes the problem spans, at least 1
+}
+
+// Error implements the error interface for a ParseError, pointing at the problem
+// with a caret underneath the offending part of the line:
+//
+//	line 2, column 5: bad location ID "4x" in right list
+//
+//	  2 | 3   4x
+//	    |     ^^
+func (e *ParseError) Error() string {
+	gutter := strconv.Itoa(e.LineNo)
+	margin := strings.Repeat(" ", len(gutter))
+
+	// Copy any tabs before the problem into the caret line so it lines up
+	// however wide the reader's tabs are
+	var pad strings.Builder
+	for _, char := range e.Line[:min(e.Col-1, len(e.Line))] {
+		if char == '\t' {
+			pad.WriteRune('\t')
+		} else {
+			pad.WriteByte(' ')
+		}
+	}
+
+	return fmt.Sprintf(
+		"line %d, column %d: %s\n\n  %s | %s\n  %s | %s%s",
+		e.LineNo,
+		e.Col,
+		e.Msg,
+		gutter,
+		e.Line,
+		margin,
+		pad.String(),
+		strings.Repeat("^", max(e.Len, 1)),
+	)
+}
+
+// field is the byte span of a whitespace separated field within a line.
+type field struct {
+	start int // Index of the first byte
+	end   int // Index one past the last byte
+}
+
+// splitFields finds the space or tab separated fields in line, filling fields
+// with as many as fit and returning how many there were in total (capped at
+// len(fields)). It doesn't allocate.
+func splitFields(line []byte, fields []field) int {
+	n := 0
+	i := 0
+	for i < len(line) && n < len(fields) {
+		for i < len(line) && parse.IsBlank(line[i]) {
+			i++
+		}
+
+		if i == len(line) {
+			break
+		}
+
+		start := i
+		for i < len(line) && !parse.IsBlank(line[i]) {
+			i++
+		}
+
+		fields[n] = field{start: start, end: i}
+		n++
+	}
+
+	return n
+}
+
+// tally is how many times each location ID appears in each list.
+//
+// That's all either part actually needs, so memory grows with the number of
+// distinct IDs rather than the length of the lists.
+type tally struct {
+	left  *counter.Counter[int] // Counts of each ID in the left list
+	right *counter.Counter[int] // Counts of each ID in the right list
+}
+
+// tallyPairs counts every left and right location ID yielded by pairs.
+func tallyPairs(pairs iter.Seq2[int, int]) tally {
+	t := tally{left: counter.New[int](), right: counter.New[int]()}
+	for left, right := range pairs {
+		t.left.Add(left)
+		t.right.Add(right)
+	}
+
+	return t
+}
+
+// StreamTotals computes the total distance and similarity score of the lists read
+// from r without ever holding the lists themselves in memory, only a count of each
+// distinct ID.
+//
+// Every line holds one ID from each list so the lists are always the same length.
+func StreamTotals(r io.Reader) (distance, similarity int, err error) {
+	pairs := newPairReader(r)
+	t := tallyPairs(pairs.All())
+	if err := pairs.Err(); err != nil {
+		return 0, 0, err
+	}
+
+	distance, err = t.distance(UnmatchedError)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return distance, t.similarity(), nil
+}
+
+// distance is the equivalent of totalDistance, pairing up the lists in sorted
+// order by walking the distinct IDs of each in order.
+func (t tally) distance(unmatched Unmatched) (int, error) {
+	if err := checkLengths(t.left.Sum(), t.right.Sum(), unmatched); err != nil {
+		return 0, err
+	}
+
+	return pairedDistance(sortedCounts(t.left), sortedCounts(t.right)), nil
+}
+
+// pairedDistance pairs up two lists given as their distinct IDs with counts, in
+// ascending order of ID, and sums the distance between each pair. Pairing stops
+// when the shorter list runs out.
+func pairedDistance(left, right []counter.Pair[int]) int {
+	sum := 0
+	i, j := 0, 0
+	for i < len(left) && j < len(right) {
+		// The next n smallest IDs in each list pair up with each other
+		n := min(left[i].Count, right[j].Count)
+		sum += n * int(math.Abs(float64(left[i].Item-right[j].Item)))
+
+		left[i].Count -= n
+		right[j].Count -= n
+
+		if left[i].Count == 0 {
+			i++
+		}
+
+		if right[j].Count == 0 {
+			j++
+		}
+	}
+
+	return sum
+}
+
+// similarity is the equivalent of similarityScore, each distinct left ID contributes
+// itself times the number of times it appears on the left times the number of times
+// it appears on the right.
+func (t tally) similarity() int {
+	sum := 0
+	for _, pair := range sortedCounts(t.left) {
+		sum += pair.Item * pair.Count * t.right.Count(pair.Item)
+	}
+
+	return sum
+}
+
+// sortedCounts returns every distinct item in the counter with its count, in
+// ascending order of item.
+func sortedCounts(counts *counter.Counter[int]) []counter.Pair[int] {
+	pairs := counts.MostCommon(counts.Size())
+	slices.SortFunc(pairs, func(a, b counter.Pair[int]) int {
+		return cmp.Compare(a.Item, b.Item)
+	})
+
+	return pairs
+}
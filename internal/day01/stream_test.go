@@ -0,0 +1,250 @@
+package day01
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/test"
+)
+
+// generator is an [io.Reader] producing lines of random location ID pairs in the
+// same shape as the puzzle input, without ever holding more than a line in memory.
+type generator struct {
+	rand  *rand.Rand // Source of location IDs
+	buf   []byte     // The unread remainder of the current line
+	lines int        // Number of lines still to generate
+}
+
+// generate returns a reader of n lines of location IDs, the same seed always
+// generates the same lines.
+func generate(n int, seed uint64) io.Reader {
+	return &generator{rand: rand.New(rand.NewPCG(seed, seed)), lines: n}
+}
+
+func (g *generator) Read(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		if len(g.buf) == 0 {
+			if g.lines == 0 {
+				if written == 0 {
+					return 0, io.EOF
+				}
+				break
+			}
+			g.lines--
+			// Real location IDs are 5 digits
+			g.buf = strconv.AppendInt(g.buf[:0], int64(10000+g.rand.IntN(90000)), 10)
+			g.buf = append(g.buf, "   "...)
+			g.buf = strconv.AppendInt(g.buf, int64(10000+g.rand.IntN(90000)), 10)
+			g.buf = append(g.buf, '\n')
+		}
+
+		n := copy(p[written:], g.buf)
+		g.buf = g.buf[n:]
+		written += n
+	}
+
+	return written, nil
+}
+
+func TestPairReader(t *testing.T) {
+	pairs := newPairReader(strings.NewReader(testInput))
+
+	var left, right []int
+	for l, r := range pairs.All() {
+		left = append(left, l)
+		right = append(right, r)
+	}
+
+	test.Ok(t, pairs.Err())
+	test.Diff(t, left, []int{3, 4, 2, 1, 3, 3})
+	test.Diff(t, right, []int{4, 3, 5, 3, 9, 3})
+}
+
+func TestPairReaderStopEarly(t *testing.T) {
+	pairs := newPairReader(strings.NewReader(testInput))
+
+	count := 0
+	for range pairs.All() {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+
+	test.Equal(t, count, 2)
+	test.Ok(t, pairs.Err())
+}
+
+func TestPairReaderErrors(t *testing.T) {
+	t.Run("bad line", func(t *testing.T) {
+		pairs := newPairReader(strings.NewReader("1   2\n3   4\nnope\n5   6\n"))
+
+		count := 0
+		for range pairs.All() {
+			count++
+		}
+
+		test.Equal(t, count, 2) // Should stop at the bad line
+		test.Err(t, pairs.Err())
+		test.True(t, strings.Contains(pairs.Err().Error(), "line 3"))
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		pairs := newPairReader(io.MultiReader(strings.NewReader("1   2\n"), iotest.ErrReader(iotest.ErrTimeout)))
+		for range pairs.All() {
+		}
+		test.Err(t, pairs.Err())
+	})
+}
+
+func TestStreamTotals(t *testing.T) {
+	distance, similarity, err := StreamTotals(strings.NewReader(testInput))
+	test.Ok(t, err)
+	test.Equal(t, distance, 11)
+	test.Equal(t, similarity, 31)
+
+	_, _, err = StreamTotals(strings.NewReader("1   x\n"))
+	test.Err(t, err)
+}
+
+func TestPairs(t *testing.T) {
+	var got [][2]int
+	for pair, err := range Pairs(strings.NewReader(testInput)) {
+		test.Ok(t, err)
+		got = append(got, pair)
+	}
+
+	want := [][2]int{{3, 4}, {4, 3}, {2, 5}, {1, 3}, {3, 9}, {3, 3}}
+	test.EqualFunc(t, got, want, slices.Equal)
+
+	var errs int
+	for _, err := range Pairs(strings.NewReader("1   2\nnope\n3   4\n")) {
+		if err != nil {
+			errs++
+		}
+	}
+	test.Equal(t, errs, 1) // Stops after the first error
+}
+
+func TestSolutionStream(t *testing.T) {
+	solution := &Solution{}
+	answers, err := solution.Stream(strings.NewReader(testInput))
+	test.Ok(t, err)
+	test.Equal(t, answers, [2]aoc.Answer{"11", "31"})
+
+	_, err = solution.Stream(strings.NewReader("1\n"))
+	test.Err(t, err)
+}
+
+func TestTallyDistanceUnmatched(t *testing.T) {
+	pairs := func(yield func(int, int) bool) {
+		_ = yield(1, 2) && yield(5, 3)
+	}
+
+	tallied := tallyPairs(pairs)
+	tallied.right.Add(100) // One more on the right with nothing to pair with
+
+	_, err := tallied.distance(UnmatchedError)
+	test.Err(t, err)
+
+	got, err := tallied.distance(UnmatchedIgnore)
+	test.Ok(t, err)
+	test.Equal(t, got, 3)
+}
+
+func TestStreamTotalsMatchesSlices(t *testing.T) {
+	for seed := range uint64(20) {
+		raw, err := io.ReadAll(generate(1000, seed))
+		test.Ok(t, err)
+
+		left, right, err := parseInput(string(raw))
+		test.Ok(t, err)
+
+		distance, similarity, err := StreamTotals(strings.NewReader(string(raw)))
+		test.Ok(t, err)
+
+		wantDistance, err := totalDistance(left, right, UnmatchedError, AlgorithmAuto)
+		test.Ok(t, err)
+
+		wantSimilarity, err := similarityScore(left, right, AlgorithmAuto)
+		test.Ok(t, err)
+
+		test.Equal(t, similarity, wantSimilarity)
+		test.Equal(t, distance, wantDistance)
+	}
+}
+
+// liveHeap returns the bytes of heap still in use once the value returned by fn is
+// all that's left alive.
+func liveHeap(fn func() any) uint64 {
+	var stats runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	before := stats.HeapAlloc
+
+	value := fn()
+
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	runtime.KeepAlive(value)
+
+	if stats.HeapAlloc < before {
+		return 0
+	}
+
+	return stats.HeapAlloc - before
+}
+
+// The slice based path holds both lists so the memory it needs grows with the input,
+// whereas the stream only holds counts of distinct IDs (at most 90,000 of them here).
+// Compare live-B/op, the memory the parsed input keeps alive, as n grows.
+func BenchmarkSolve(b *testing.B) {
+	for _, n := range []int{1_000, 100_000, 1_000_000} {
+		raw, err := io.ReadAll(generate(n, 1))
+		test.Ok(b, err)
+
+		b.Run(fmt.Sprintf("slices/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ReportMetric(float64(liveHeap(func() any {
+				left, right, _ := parseInput(string(raw))
+				return [][]int{left, right}
+			})), "live-B/op")
+
+			for range b.N {
+				left, right, err := parseInput(string(raw))
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := similarityScore(left, right, AlgorithmAuto); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := totalDistance(left, right, UnmatchedError, AlgorithmAuto); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("stream/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ReportMetric(float64(liveHeap(func() any {
+				return tallyPairs(newPairReader(generate(n, 1)).All())
+			})), "live-B/op")
+
+			for range b.N {
+				if _, _, err := StreamTotals(generate(n, 1)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
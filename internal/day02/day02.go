@@ -64,100 +64,139 @@ Thanks to the Problem Dampener, 4 reports are actually safe!
 Update your analysis by handling situations where the Problem Dampener can remove a single level from unsafe reports. How many reports are now safe?
 */
 
-package main
+package day02
 
 import (
-	"bufio"
 	_ "embed"
-	"fmt"
-	"math"
-	"os"
-	"strconv"
 	"strings"
 
-	"github.com/FollowTheProcess/msg"
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
 )
 
 //go:embed day02.txt
 var input string
 
-func main() {
-	if err := run(input); err != nil {
-		msg.Error("%v", err)
-		os.Exit(1)
+//go:embed answers.txt
+var answers string
+
+// Day returns the day 2 puzzle, ready to be registered with the runner.
+func Day() aoc.Day {
+	return aoc.Day{
+		Number:  2,
+		Input:   input,
+		Answers: answers,
+		New:     func() aoc.Solution { return &Solution{} },
 	}
 }
 
-func run(input string) error {
+// Solution is the solution to day 2, it implements [aoc.Solution].
+type Solution struct {
+	tally       *tally        // Every report classified, shared by both parts once worked out
+	Policy      *SafetyPolicy // The rules for a safe report, nil means the puzzle's
+	reports     reportSet     // The reports from the red-nosed reactor
+	tallyPolicy SafetyPolicy  // The policy tally was worked out under
+	Workers     int           // How many goroutines classify reports, less than 1 means one per CPU
+}
+
+// Parse parses the reactor reports from the puzzle input.
+func (s *Solution) Parse(input string) error {
 	reports, err := parseInput(input)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Part 1: %d\n", countSafe(reports))
-	fmt.Printf("Part 2: %d\n", countSafeRelaxed(reports))
+	s.reports = reports
+	s.tally = nil
 
 	return nil
 }
 
-// parseInput parses a list of Reports from the puzzle input.
-func parseInput(input string) ([]Report, error) {
-	input = strings.TrimSpace(input)
+// Part1 returns the number of safe reports.
+func (s *Solution) Part1() (aoc.Answer, error) {
+	t, err := s.evaluate()
+	if err != nil {
+		return "", err
+	}
 
-	scanner := bufio.NewScanner(strings.NewReader(input))
-	lineNo := 1
+	return aoc.Int(t.safe), nil
+}
 
-	var reports []Report
+// Part2 returns the number of safe reports once the problem dampener is
+// taken into account.
+func (s *Solution) Part2() (aoc.Answer, error) {
+	t, err := s.evaluate()
+	if err != nil {
+		return "", err
+	}
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		rawLevels := strings.Split(line, " ")
+	return aoc.Int(t.safe + t.dampened), nil
+}
 
-		report := make([]int, 0, len(rawLevels))
+// evaluate classifies every report the first time either part asks, so both
+// answers come from a single pass over the reports.
+func (s *Solution) evaluate() (tally, error) {
+	policy := s.policy()
+	if err := policy.Validate(); err != nil {
+		return tally{}, err
+	}
 
-		for _, rawLevel := range rawLevels {
-			level, err := strconv.Atoi(rawLevel)
-			if err != nil {
-				return nil, fmt.Errorf("bad level (%q in %q) on line %d: %w", rawLevel, line, lineNo, err)
-			}
-			report = append(report, level)
-		}
+	if s.tally == nil || s.tallyPolicy != policy {
+		t := evaluate(s.reports, policy, s.Workers)
+		s.tally = &t
+		s.tallyPolicy = policy
+	}
 
-		reports = append(reports, report)
+	return *s.tally, nil
+}
 
-		lineNo++
+// policy returns the safety policy in force, the puzzle's if none was given.
+func (s *Solution) policy() SafetyPolicy {
+	return orDefault(s.Policy)
+}
+
+// parseInput parses the Reports from the puzzle input into a single flat
+// reportSet.
+//
+// Levels may be separated by any run of spaces or tabs, blank lines are skipped
+// and \r\n line endings are fine. A first pass counts the lines and levels so the
+// storage can be allocated exactly once, which makes parsing O(1) allocations
+// however big the input.
+func parseInput(input string) (reportSet, error) {
+	lines, levels := countLevels(input)
+	set := reportSet{
+		levels:  make([]int32, 0, levels),
+		offsets: make([]int, 1, lines+1),
 	}
 
-	return reports, nil
-}
+	lineNo := 0
+	for len(input) > 0 {
+		lineNo++
 
-// countSafe returns the number of reports that are safe.
-func countSafe(reports []Report) int {
-	safe := 0
-	for _, report := range reports {
-		if report.IsSafe() {
-			safe++
+		line := input
+		if end := strings.IndexByte(input, '\n'); end != -1 {
+			line, input = input[:end], input[end+1:]
+		} else {
+			input = ""
 		}
-	}
 
-	return safe
-}
+		before := len(set.levels)
+
+		var err error
+		set.levels, err = appendLevels(set.levels, line, lineNo)
+		if err != nil {
+			return reportSet{}, err
+		}
 
-// countSafeRelaxed returns the number of reports that are safe with
-// the problem dampener taken into account.
-func countSafeRelaxed(reports []Report) int {
-	safe := 0
-	for _, report := range reports {
-		if report.IsSafeRelaxed() {
-			safe++
+		if len(set.levels) != before {
+			set.offsets = append(set.offsets, len(set.levels))
 		}
 	}
 
-	return safe
+	return set, nil
 }
 
 // Report repesents a report from the red-nosed reactor.
-type Report []int
+type Report []int32
 
 // IsSafe reports whether the report is safe according to the puzzle criteria.
 //
@@ -165,80 +204,23 @@ type Report []int
 //   - The levels are either all increasing or all decreasing.
 //   - Any two adjacent levels differ by at least one and at most three.
 func (r Report) IsSafe() bool {
-	// Yes this iterates through the slice 3 times but it's advent of code
-	// so who cares
-	if !r.allIncreasing() && !r.allDecreasing() {
-		return false
-	}
-
-	if !r.differenceAllowed() {
-		return false
-	}
-
-	return true
+	return DefaultPolicy().Safe(r)
 }
 
-// IsSafeRelaxed is like IsSafe but takes the problem dampener into account.
+// IsSafeRelaxed is like IsSafe but takes the problem dampener into account, a
+// report that would be safe with any single level removed is also safe.
 func (r Report) IsSafeRelaxed() bool {
-	if r.IsSafe() {
-		// If it's safe already it's obviously still safe
-		return true
-	}
-
-	// Remove one at a time and test for safety
-	for i := 0; i < len(r); i++ {
-		removed := append(append([]int{}, r[0:i]...), r[i+1:]...)
-		report := Report(removed)
-		if report.IsSafe() {
-			return true
-		}
-	}
-
-	// Still not safe
-	return false
-}
-
-// allDecreasing reports whether the Report contains values that are
-// always decreasing e.g. 5, 4, 3, 2, 1.
-func (r Report) allDecreasing() bool {
-	for i := 1; i < len(r); i++ {
-		if r[i] >= r[i-1] {
-			// Next element is greater than or equal to the previous
-			// so it can't be all decreasing
-			return false
-		}
-	}
-
-	return true
+	return DefaultPolicy().SafeDampened(r)
 }
 
-// allIncreasing reports whether the Report contains values that are
-// always increasing e.g. 1, 2, 3, 4, 5.
-func (r Report) allIncreasing() bool {
-	for i := 1; i < len(r); i++ {
-		if r[i] <= r[i-1] {
-			// Next element is less than or equal to the previous
-			// so it can't be all increasing
-			return false
-		}
-	}
-
-	return true
+// step returns the change in level from index i to index j, worked out as an int
+// so it can't overflow.
+func (r Report) step(i, j int) int {
+	return int(r[j]) - int(r[i])
 }
 
-// differenceAllowed reports whether the difference between any two adjacent levels
-// is allowed.
-func (r Report) differenceAllowed() bool {
-	for i := 1; i < len(r); i++ {
-		current := r[i]
-		previous := r[i-1]
-
-		diff := int(math.Abs(float64(current - previous)))
-
-		if diff > 3 || diff < 1 {
-			return false
-		}
-	}
-
-	return true
+// MinRemovals returns the indices of the fewest levels that must be removed to make
+// the report safe by the puzzle's rules, see [SafetyPolicy.MinRemovals].
+func (r Report) MinRemovals() []int {
+	return DefaultPolicy().MinRemovals(r)
 }
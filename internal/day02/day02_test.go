@@ -0,0 +1,265 @@
+package day02
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/test"
+)
+
+const testInput = `
+7 6 4 2 1
+1 2 7 8 9
+9 7 6 2 1
+1 3 2 4 5
+8 6 4 4 1
+1 3 6 7 9
+`
+
+func TestParse(t *testing.T) {
+	set, err := parseInput(testInput)
+	test.Ok(t, err)
+
+	reports := slices.Collect(set.All())
+
+	want := []Report{
+		{7, 6, 4, 2, 1},
+		{1, 2, 7, 8, 9},
+		{9, 7, 6, 2, 1},
+		{1, 3, 2, 4, 5},
+		{8, 6, 4, 4, 1},
+		{1, 3, 6, 7, 9},
+	}
+
+	test.Diff(t, reports, want)
+}
+
+func TestCountSafe(t *testing.T) {
+	t.Run("no dampener", func(t *testing.T) {
+		reports := []Report{
+			{7, 6, 4, 2, 1},
+			{1, 2, 7, 8, 9},
+			{9, 7, 6, 2, 1},
+			{1, 3, 2, 4, 5},
+			{8, 6, 4, 4, 1},
+			{1, 3, 6, 7, 9},
+		}
+
+		want := 2
+
+		got := evaluate(flatten(reports), DefaultPolicy(), 1).safe
+
+		test.Equal(t, got, want) // countSafe returned the wrong answer
+	})
+	t.Run("with dampener", func(t *testing.T) {
+		reports := []Report{
+			{7, 6, 4, 2, 1},
+			{1, 2, 7, 8, 9},
+			{9, 7, 6, 2, 1},
+			{1, 3, 2, 4, 5},
+			{8, 6, 4, 4, 1},
+			{1, 3, 6, 7, 9},
+		}
+
+		want := 4
+
+		counts := evaluate(flatten(reports), DefaultPolicy(), 1)
+		got := counts.safe + counts.dampened
+
+		test.Equal(t, got, want) // countSafe returned the wrong answer
+	})
+}
+
+func TestReport(t *testing.T) {
+	tests := []struct {
+		name          string // Name of the test case
+		report        Report // The report under test
+		isSafe        bool   // Whether the report should be considered safe
+		allDecreasing bool   // Whether the report is all decreasing
+		allIncreasing bool   // Whether the report is all increasing
+	}{
+		{
+			name:          "example 1",
+			report:        Report{7, 6, 4, 2, 1},
+			isSafe:        true, // because the levels are all decreasing by 1 or 2
+			allDecreasing: true,
+		},
+		{
+			name:          "example 2",
+			report:        Report{1, 2, 7, 8, 9},
+			isSafe:        false, // because 2 7 is an increase of 5
+			allIncreasing: true,
+		},
+		{
+			name:          "example 3",
+			report:        Report{9, 7, 6, 2, 1},
+			isSafe:        false, // because 6 2 is a decrease of 4.
+			allDecreasing: true,
+		},
+		{
+			name:   "example 4",
+			report: Report{1, 3, 2, 4, 5},
+			isSafe: false, // because 1 3 is increasing but 3 2 is decreasing.
+		},
+		{
+			name:          "example 5",
+			report:        Report{8, 6, 4, 4, 1},
+			isSafe:        false, // because 4 4 is neither an increase or a decrease.
+			allDecreasing: false,
+		},
+		{
+			name:          "example 6",
+			report:        Report{1, 3, 6, 7, 9},
+			isSafe:        true, // because the levels are all increasing by 1, 2, or 3.
+			allIncreasing: true,
+		},
+	}
+
+	// Any size of step, so long as it's in the right direction
+	increasing := SafetyPolicy{MinStep: 1, MaxStep: math.MaxInt, Direction: DirectionIncreasing}
+	decreasing := SafetyPolicy{MinStep: 1, MaxStep: math.MaxInt, Direction: DirectionDecreasing}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.Equal(t, increasing.Safe(tt.report), tt.allIncreasing) // allIncreasing mismatch
+			test.Equal(t, decreasing.Safe(tt.report), tt.allDecreasing) // allDecreasing mismatch
+			test.Equal(t, tt.report.IsSafe(), tt.isSafe)                // IsSafe() mismatch
+		})
+	}
+}
+
+func TestSolution(t *testing.T) {
+	solution := &Solution{}
+	test.Ok(t, solution.Parse(testInput))
+
+	part1, err := solution.Part1()
+	test.Ok(t, err)
+	test.Equal(t, part1, aoc.Answer("2")) // Wrong answer for part 1 example
+
+	part2, err := solution.Part2()
+	test.Ok(t, err)
+	test.Equal(t, part2, aoc.Answer("4")) // Wrong answer for part 2 example
+}
+
+// isSafeRelaxedBruteForce is the original problem dampener, trying the report with
+// each level removed in turn. It's the reference IsSafeRelaxed is checked against.
+func isSafeRelaxedBruteForce(r Report) bool {
+	if r.IsSafe() {
+		return true
+	}
+
+	for i := range r {
+		removed := append(append(Report{}, r[0:i]...), r[i+1:]...)
+		if Report(removed).IsSafe() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestIsSafeRelaxed(t *testing.T) {
+	tests := []struct {
+		name   string // Name of the test case
+		report Report // The report under test
+		want   bool   // Whether it's safe with the dampener
+	}{
+		{name: "empty", report: Report{}, want: true},
+		{name: "single", report: Report{1}, want: true},
+		{name: "pair too far apart", report: Report{1, 9}, want: true},
+		{name: "already safe", report: Report{7, 6, 4, 2, 1}, want: true},
+		{name: "example 2", report: Report{1, 2, 7, 8, 9}, want: false},
+		{name: "example 3", report: Report{9, 7, 6, 2, 1}, want: false},
+		{name: "example 4", report: Report{1, 3, 2, 4, 5}, want: true},
+		{name: "example 5", report: Report{8, 6, 4, 4, 1}, want: true},
+		{name: "remove first", report: Report{5, 1, 2, 3, 4}, want: true},
+		{name: "remove last", report: Report{1, 2, 3, 4, 9}, want: true},
+		{name: "remove first of bad pair", report: Report{1, 2, 9, 3, 4}, want: true},
+		{name: "wrong direction first", report: Report{3, 1, 2, 3, 4}, want: true},
+		{name: "two bad levels", report: Report{1, 9, 2, 9, 3}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.Equal(t, tt.report.IsSafeRelaxed(), tt.want)
+			test.Equal(t, isSafeRelaxedBruteForce(tt.report), tt.want)
+		})
+	}
+}
+
+func TestIsSafeRelaxedAllocations(t *testing.T) {
+	report := Report{1, 2, 9, 3, 4, 5, 6, 7}
+	allocs := testing.AllocsPerRun(100, func() {
+		report.IsSafeRelaxed()
+	})
+
+	test.Equal(t, allocs, 0.0)
+}
+
+// FuzzIsSafeRelaxed checks the linear problem dampener against the brute force
+// one. Each byte is a level, kept small so that safe and nearly safe reports are
+// common.
+func FuzzIsSafeRelaxed(f *testing.F) {
+	f.Add([]byte{7, 6, 4, 2, 1})
+	f.Add([]byte{1, 2, 7, 8, 9})
+	f.Add([]byte{1, 3, 2, 4, 5})
+	f.Add([]byte{8, 6, 4, 4, 1})
+	f.Add([]byte{5, 1, 2, 3, 4})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		report := make(Report, len(data))
+		for i, b := range data {
+			report[i] = int32(b % 16)
+		}
+
+		test.Equal(t, report.IsSafeRelaxed(), isSafeRelaxedBruteForce(report))
+	})
+}
+
+// longReport returns an increasing report of n levels with a single bad level in
+// the middle, the worst case for the brute force dampener as every removal up to
+// that point has to be tried.
+func longReport(n int, seed uint64) Report {
+	r := rand.New(rand.NewPCG(seed, seed))
+	report := make(Report, 0, n)
+	level := int32(0)
+	for i := range n {
+		if i == n/2 && n > 2 {
+			// A spike that removing puts everything right
+			report = append(report, level+10)
+			continue
+		}
+
+		level += 1 + r.Int32N(3)
+		report = append(report, level)
+	}
+
+	return report
+}
+
+func BenchmarkIsSafeRelaxed(b *testing.B) {
+	for _, n := range []int{10, 100, 1000, 10_000} {
+		report := longReport(n, 1)
+		if !report.IsSafeRelaxed() {
+			b.Fatalf("longReport(%d) should be safe with the dampener", n)
+		}
+
+		b.Run(fmt.Sprintf("linear/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				report.IsSafeRelaxed()
+			}
+		})
+
+		b.Run(fmt.Sprintf("brute/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				isSafeRelaxedBruteForce(report)
+			}
+		})
+	}
+}
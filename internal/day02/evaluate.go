@@ -0,0 +1,137 @@
+package day02
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// chunkSize is how many reports a worker classifies at a time, big enough that
+// handing out work is cheap next to doing it.
+const chunkSize = 4096
+
+// Class is how safe a report is.
+type Class int
+
+const (
+	// ClassSafe is a report that's safe as it is.
+	ClassSafe Class = iota
+
+	// ClassDampened is a report that's only safe thanks to the problem dampener.
+	ClassDampened
+
+	// ClassUnsafe is a report that isn't safe either way.
+	ClassUnsafe
+)
+
+// String implements [fmt.Stringer] for a Class.
+func (c Class) String() string {
+	switch c {
+	case ClassSafe:
+		return "safe"
+	case ClassDampened:
+		return "safe with dampener"
+	case ClassUnsafe:
+		return "unsafe"
+	default:
+		return fmt.Sprintf("Class(%d)", int(c))
+	}
+}
+
+// Classify returns the Class of the report under the policy.
+func (p SafetyPolicy) Classify(r Report) Class {
+	switch {
+	case p.Safe(r):
+		return ClassSafe
+	case p.SafeDampened(r):
+		return ClassDampened
+	default:
+		return ClassUnsafe
+	}
+}
+
+// tally is how many reports there are of each Class.
+type tally struct {
+	safe     int // Reports that are safe as they are
+	dampened int // Reports only safe with the problem dampener
+	unsafe   int // Reports that aren't safe either way
+}
+
+// add counts one report of the given class.
+func (t *tally) add(class Class) {
+	switch class {
+	case ClassSafe:
+		t.safe++
+	case ClassDampened:
+		t.dampened++
+	case ClassUnsafe:
+		t.unsafe++
+	}
+}
+
+// merge adds the counts from other.
+func (t *tally) merge(other tally) {
+	t.safe += other.safe
+	t.dampened += other.dampened
+	t.unsafe += other.unsafe
+}
+
+// evaluate classifies every report once under policy, spread across up to workers
+// goroutines, or one per CPU if workers is less than 1.
+//
+// Reports are handed out in chunks and each worker keeps its own tally, so the
+// only synchronisation is handing out chunks and collecting one tally per worker.
+func evaluate(reports reportSet, policy SafetyPolicy, workers int) tally {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	chunks := (reports.Len() + chunkSize - 1) / chunkSize
+	workers = min(workers, chunks)
+	if workers <= 1 {
+		return evaluateChunk(reports, policy)
+	}
+
+	jobs := make(chan reportSet)
+	results := make(chan tally, workers)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var t tally
+			for chunk := range jobs {
+				t.merge(evaluateChunk(chunk, policy))
+			}
+
+			results <- t
+		}()
+	}
+
+	for start := 0; start < reports.Len(); start += chunkSize {
+		jobs <- reports.Slice(start, min(start+chunkSize, reports.Len()))
+	}
+	close(jobs)
+
+	wg.Wait()
+	close(results)
+
+	var total tally
+	for t := range results {
+		total.merge(t)
+	}
+
+	return total
+}
+
+// evaluateChunk classifies every report in turn on the calling goroutine.
+func evaluateChunk(reports reportSet, policy SafetyPolicy) tally {
+	var t tally
+	for report := range reports.All() {
+		t.add(policy.Classify(report))
+	}
+
+	return t
+}
@@ -0,0 +1,140 @@
+package day02
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/test"
+)
+
+// randomReports returns n reports shaped like the puzzle input: 5 to 8 levels,
+// mostly heading steadily one way with the odd level out of place.
+func randomReports(n int, seed uint64) reportSet {
+	r := rand.New(rand.NewPCG(seed, seed))
+	reports := make([]Report, n)
+	for i := range reports {
+		report := make(Report, 5+r.IntN(4))
+		report[0] = 1 + r.Int32N(90)
+
+		sign := int32(1)
+		if r.IntN(2) == 0 {
+			sign = -1
+		}
+
+		for j := 1; j < len(report); j++ {
+			step := sign * (1 + r.Int32N(3))
+			if r.IntN(8) == 0 {
+				step = r.Int32N(9) - 4
+			}
+			report[j] = report[j-1] + step
+		}
+
+		reports[i] = report
+	}
+
+	return flatten(reports)
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name   string // Name of the test case
+		report Report // The report under test
+		want   Class  // Expected class
+	}{
+		{name: "example 1", report: Report{7, 6, 4, 2, 1}, want: ClassSafe},
+		{name: "example 2", report: Report{1, 2, 7, 8, 9}, want: ClassUnsafe},
+		{name: "example 3", report: Report{9, 7, 6, 2, 1}, want: ClassUnsafe},
+		{name: "example 4", report: Report{1, 3, 2, 4, 5}, want: ClassDampened},
+		{name: "example 5", report: Report{8, 6, 4, 4, 1}, want: ClassDampened},
+		{name: "example 6", report: Report{1, 3, 6, 7, 9}, want: ClassSafe},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.Equal(t, DefaultPolicy().Classify(tt.report), tt.want)
+		})
+	}
+}
+
+// Run with -race to check the workers share nothing they shouldn't.
+func TestEvaluateConcurrent(t *testing.T) {
+	// Several chunks, the last one partial
+	reports := randomReports(10*chunkSize+123, 1)
+
+	policy := DefaultPolicy()
+	var want tally
+	for report := range reports.All() {
+		want.add(policy.Classify(report))
+	}
+
+	// Make sure the reports cover every class
+	test.True(t, want.safe > 0)
+	test.True(t, want.dampened > 0)
+	test.True(t, want.unsafe > 0)
+
+	for _, workers := range []int{-1, 0, 1, 2, 3, 8, 100} {
+		t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+			t.Parallel()
+			test.Equal(t, evaluate(reports, policy, workers), want)
+		})
+	}
+}
+
+func TestEvaluateEmpty(t *testing.T) {
+	test.Equal(t, evaluate(reportSet{}, DefaultPolicy(), 4), tally{})
+}
+
+func TestSolutionWorkers(t *testing.T) {
+	for _, workers := range []string{"1", "4"} {
+		t.Run(workers, func(t *testing.T) {
+			solution := &Solution{}
+			test.Ok(t, solution.Set("workers", workers))
+			test.Ok(t, solution.Parse(input))
+
+			part1, err := solution.Part1()
+			test.Ok(t, err)
+			test.Equal(t, part1, aoc.Answer("598"))
+
+			part2, err := solution.Part2()
+			test.Ok(t, err)
+			test.Equal(t, part2, aoc.Answer("634"))
+		})
+	}
+
+	test.Err(t, (&Solution{}).Set("workers", "lots"))
+}
+
+func BenchmarkEvaluate(b *testing.B) {
+	reports := randomReports(1_000_000, 1)
+	policy := DefaultPolicy()
+
+	b.Run("two passes", func(b *testing.B) {
+		for range b.N {
+			safe, relaxed := 0, 0
+			for report := range reports.All() {
+				if policy.Safe(report) {
+					safe++
+				}
+				if policy.SafeDampened(report) {
+					relaxed++
+				}
+			}
+		}
+	})
+
+	counts := []int{1, 2, 4, runtime.NumCPU()}
+	slices.Sort(counts)
+
+	for _, workers := range slices.Compact(counts) {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				evaluate(reports, policy, workers)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package day02
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+)
+
+// histogramWidth is the length of the longest bar in the histogram of removals.
+const histogramWidth = 40
+
+// summary explains why each unsafe report fails and how often each rule is broken
+// across the whole input.
+type summary struct {
+	Unsafe   []unsafeReport `json:"unsafe"`   // Every report that isn't safe without the dampener
+	Reasons  []reason       `json:"reasons"`  // How often each rule was broken, in the order of rules
+	Removals []int          `json:"removals"` // Histogram of reports by the fewest levels to remove to make them safe
+	Reports  int            `json:"reports"`  // How many reports there are in total
+	Safe     int            `json:"safe"`     // The part 1 answer
+	Dampened int            `json:"dampened"` // The part 2 answer
+}
+
+// unsafeReport is a report that isn't safe and everything wrong with it.
+type unsafeReport struct {
+	Levels     Report      `json:"levels"`     // The report's levels
+	Violations []Violation `json:"violations"` // Every rule it breaks
+	Remove     []int       `json:"remove"`     // The 0 based indices of the fewest levels to remove to make it safe
+	Number     int         `json:"number"`     // The 1 based position of the report in the input
+	Dampened   bool        `json:"dampened"`   // Whether the problem dampener makes it safe
+}
+
+// reason is one rule and how often it was broken.
+type reason struct {
+	Rule       Rule `json:"rule"`       // The rule
+	Violations int  `json:"violations"` // How many pairs of levels broke it
+	Reports    int  `json:"reports"`    // How many reports broke it at least once
+}
+
+// Explain summarises why each report is unsafe, it implements [aoc.Explainer].
+func (s *Solution) Explain() (aoc.Explanation, error) {
+	policy := s.policy()
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return summarise(s.reports, policy), nil
+}
+
+// summarise builds the summary of the reports under policy.
+func summarise(reports reportSet, policy SafetyPolicy) summary {
+	sum := summary{Reports: reports.Len()}
+	for _, rule := range rules {
+		sum.Reasons = append(sum.Reasons, reason{Rule: rule})
+	}
+
+	for i := range reports.Len() {
+		report := reports.At(i)
+		remove := policy.MinRemovals(report)
+		for len(sum.Removals) <= len(remove) {
+			sum.Removals = append(sum.Removals, 0)
+		}
+		sum.Removals[len(remove)]++
+
+		violations := policy.Violations(report)
+		if len(violations) == 0 {
+			sum.Safe++
+			sum.Dampened++
+			continue
+		}
+
+		dampened := policy.SafeDampened(report)
+		if dampened {
+			sum.Dampened++
+		}
+
+		var broken [len(rules)]bool
+		for _, violation := range violations {
+			sum.Reasons[violation.Rule].Violations++
+			broken[violation.Rule] = true
+		}
+
+		for rule, broke := range broken {
+			if broke {
+				sum.Reasons[rule].Reports++
+			}
+		}
+
+		sum.Unsafe = append(sum.Unsafe, unsafeReport{
+			Levels:     report,
+			Violations: violations,
+			Remove:     remove,
+			Number:     i + 1,
+			Dampened:   dampened,
+		})
+	}
+
+	return sum
+}
+
+// String renders the summary as a list of unsafe reports followed by a table of
+// the reasons they failed.
+func (s summary) String() string {
+	b := &strings.Builder{}
+
+	for _, report := range s.Unsafe {
+		verdict := "unsafe"
+		if report.Dampened {
+			verdict = "safe with the problem dampener"
+		}
+
+		fmt.Fprintf(b, "Report %d: %s (%s)\n", report.Number, report.Levels, verdict)
+		for _, violation := range report.Violations {
+			fmt.Fprintf(b, "  %s\n", violation)
+		}
+
+		levels := make([]string, 0, len(report.Remove))
+		for _, index := range report.Remove {
+			levels = append(levels, strconv.Itoa(index+1))
+		}
+		fmt.Fprintf(b, "  fewest levels to remove: %s\n", strings.Join(levels, ", "))
+	}
+
+	if len(s.Unsafe) != 0 {
+		fmt.Fprintln(b)
+	}
+
+	fmt.Fprintf(b, "Safe reports: %d of %d\n", s.Safe, s.Reports)
+	fmt.Fprintf(b, "Safe with the problem dampener: %d of %d\n\n", s.Dampened, s.Reports)
+
+	tab := tabwriter.NewWriter(b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tab, "REASON\tVIOLATIONS\tREPORTS")
+	for _, reason := range s.Reasons {
+		fmt.Fprintf(tab, "%s\t%d\t%d\n", reason.Rule, reason.Violations, reason.Reports)
+	}
+
+	// Lines without cells split the tables into separately aligned blocks
+	fmt.Fprintln(tab)
+
+	most := slices.Max(append([]int{1}, s.Removals...))
+	fmt.Fprintln(tab, "REMOVALS\tREPORTS\tHISTOGRAM")
+	for removals, reports := range s.Removals {
+		bar := strings.Repeat("#", (reports*histogramWidth+most-1)/most)
+		fmt.Fprintf(tab, "%d\t%d\t%s\n", removals, reports, bar)
+	}
+
+	tab.Flush() //nolint:errcheck // Writing to a strings.Builder never fails
+
+	return b.String()
+}
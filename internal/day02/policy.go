@@ -0,0 +1,301 @@
+package day02
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Direction is the way a report's levels are required to go.
+type Direction int
+
+const (
+	// DirectionAny allows reports that are either all increasing or all decreasing.
+	DirectionAny Direction = iota
+
+	// DirectionIncreasing only allows reports whose levels go up.
+	DirectionIncreasing
+
+	// DirectionDecreasing only allows reports whose levels go down.
+	DirectionDecreasing
+)
+
+// ParseDirection parses a Direction from its String form.
+func ParseDirection(raw string) (Direction, error) {
+	for _, direction := range [...]Direction{DirectionAny, DirectionIncreasing, DirectionDecreasing} {
+		if raw == direction.String() {
+			return direction, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown direction %q, expected any, increasing or decreasing", raw)
+}
+
+// String implements [fmt.Stringer] for a Direction.
+func (d Direction) String() string {
+	switch d {
+	case DirectionAny:
+		return "any"
+	case DirectionIncreasing:
+		return "increasing"
+	case DirectionDecreasing:
+		return "decreasing"
+	default:
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+}
+
+// signs returns the signs a report's steps may have under d, +1 for increasing
+// and -1 for decreasing.
+func (d Direction) signs() []int {
+	switch d {
+	case DirectionIncreasing:
+		return []int{1}
+	case DirectionDecreasing:
+		return []int{-1}
+	default:
+		return []int{1, -1}
+	}
+}
+
+// SafetyPolicy is the set of rules deciding whether a report is safe.
+//
+// The zero value is a real policy, only allowing reports whose levels never change,
+// use [DefaultPolicy] for the puzzle's own rules.
+type SafetyPolicy struct {
+	MinStep       int       // Smallest allowed change between adjacent levels
+	MaxStep       int       // Largest allowed change between adjacent levels
+	Removals      int       // How many levels the problem dampener may remove
+	Direction     Direction // Which way the levels must go
+	AllowPlateaus bool      // Whether adjacent levels may be equal regardless of MinStep
+}
+
+// DefaultPolicy returns the puzzle's rules: levels must all increase or all
+// decrease by between 1 and 3, and the problem dampener may remove one level.
+func DefaultPolicy() SafetyPolicy {
+	return SafetyPolicy{MinStep: 1, MaxStep: 3, Removals: 1}
+}
+
+// orDefault returns the policy p points to, or DefaultPolicy if p is nil.
+func orDefault(p *SafetyPolicy) SafetyPolicy {
+	if p == nil {
+		return DefaultPolicy()
+	}
+
+	return *p
+}
+
+// Validate reports whether the policy makes sense.
+func (p SafetyPolicy) Validate() error {
+	var errs []error
+	if p.MinStep < 0 {
+		errs = append(errs, fmt.Errorf("min step must not be negative, got %d", p.MinStep))
+	}
+
+	if p.MaxStep < p.MinStep {
+		errs = append(errs, fmt.Errorf("max step (%d) must not be less than min step (%d)", p.MaxStep, p.MinStep))
+	}
+
+	if p.Removals < 0 {
+		errs = append(errs, fmt.Errorf("removals must not be negative, got %d", p.Removals))
+	}
+
+	if p.Direction < DirectionAny || p.Direction > DirectionDecreasing {
+		errs = append(errs, fmt.Errorf("unknown direction %s", p.Direction))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Safe reports whether the report is safe under the policy without the problem
+// dampener.
+func (p SafetyPolicy) Safe(r Report) bool {
+	for _, sign := range p.Direction.signs() {
+		if p.firstUnsafe(r, sign, noSkip) == -1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SafeDampened reports whether the report is safe under the policy once the
+// problem dampener has removed up to p.Removals levels.
+//
+// Removing a single level runs in linear time without allocating: if a report
+// isn't safe heading one way, the first pair of adjacent levels breaking the rules
+// must lose one of its two levels, as removing anything else leaves that pair next
+// to each other. So at most two candidate removals need checking in each direction.
+// Removing more than one falls back to MinRemovals, which is quadratic.
+func (p SafetyPolicy) SafeDampened(r Report) bool {
+	switch {
+	case p.Removals <= 0:
+		return p.Safe(r)
+	case p.Removals == 1:
+		for _, sign := range p.Direction.signs() {
+			bad := p.firstUnsafe(r, sign, noSkip)
+			if bad == -1 {
+				return true
+			}
+
+			if p.firstUnsafe(r, sign, bad) == -1 || p.firstUnsafe(r, sign, bad+1) == -1 {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return len(p.MinRemovals(r)) <= p.Removals
+	}
+}
+
+// noSkip tells firstUnsafe not to skip any level.
+const noSkip = -1
+
+// firstUnsafe returns the index of the first level that, along with the level
+// after it, breaks the policy for a report heading in the direction of sign, or
+// -1 if the whole report is safe.
+//
+// The level at index skip is treated as if it had been removed, pass noSkip to
+// check every level.
+func (p SafetyPolicy) firstUnsafe(r Report, sign, skip int) int {
+	previous := -1
+	for i := range r {
+		if i == skip {
+			continue
+		}
+
+		if previous != -1 && !p.allowed(r.step(previous, i)*sign) {
+			return previous
+		}
+
+		previous = i
+	}
+
+	return -1
+}
+
+// allowed reports whether step, the change between two adjacent levels with the
+// required direction as positive, is allowed by the policy.
+func (p SafetyPolicy) allowed(step int) bool {
+	if step == 0 && p.AllowPlateaus {
+		return true
+	}
+
+	return step >= p.MinStep && step <= p.MaxStep
+}
+
+// MinRemovals returns the indices, in ascending order, of the fewest levels that
+// must be removed from the report to make it safe under the policy. It's empty if
+// the report is already safe. There may be other sets of the same size, this is
+// just one of them.
+//
+// The levels left behind are a subsequence where every adjacent pair is allowed,
+// so this is a longest increasing subsequence problem with the policy's steps as
+// the constraint, solved with the classic O(n²) dynamic programme in each
+// permitted direction.
+func (p SafetyPolicy) MinRemovals(r Report) []int {
+	if len(r) == 0 {
+		return nil
+	}
+
+	// longest[i] is the length of the longest allowed subsequence ending at level
+	// i, and previous[i] the level before i in it, or -1 if it starts at i
+	longest := make([]int, len(r))
+	previous := make([]int, len(r))
+
+	var keep []bool
+	best := 0
+	for _, sign := range p.Direction.signs() {
+		end := 0
+		for i := range r {
+			longest[i], previous[i] = 1, -1
+			for j := range i {
+				if longest[j]+1 > longest[i] && p.allowed(r.step(j, i)*sign) {
+					longest[i], previous[i] = longest[j]+1, j
+				}
+			}
+
+			if longest[i] > longest[end] {
+				end = i
+			}
+		}
+
+		if longest[end] > best {
+			best = longest[end]
+			keep = make([]bool, len(r))
+			for i := end; i != -1; i = previous[i] {
+				keep[i] = true
+			}
+		}
+	}
+
+	removals := make([]int, 0, len(r)-best)
+	for i, kept := range keep {
+		if !kept {
+			removals = append(removals, i)
+		}
+	}
+
+	return removals
+}
+
+// policyOptions are the names of the options SafetyPolicy.Set accepts.
+var policyOptions = [...]string{"min-step", "max-step", "removals", "plateaus", "direction"}
+
+// Set sets one of the policy's rules from its string form, leaving the others as
+// they are.
+//
+// The options are min-step, max-step, removals, plateaus and direction.
+func (p *SafetyPolicy) Set(name, value string) error {
+	var err error
+	switch name {
+	case "min-step":
+		p.MinStep, err = strconv.Atoi(value)
+	case "max-step":
+		p.MaxStep, err = strconv.Atoi(value)
+	case "removals":
+		p.Removals, err = strconv.Atoi(value)
+	case "plateaus":
+		p.AllowPlateaus, err = strconv.ParseBool(value)
+	case "direction":
+		p.Direction, err = ParseDirection(value)
+	default:
+		return fmt.Errorf("unknown option %q, expected one of %s", name, strings.Join(policyOptions[:], ", "))
+	}
+
+	if err != nil {
+		return fmt.Errorf("bad value %q for option %s: %w", value, name, err)
+	}
+
+	return nil
+}
+
+// Set implements [aoc.Configurable], setting one of the safety policy's rules, see
+// [SafetyPolicy.Set], or workers for how many goroutines classify the reports.
+//
+// The first rule set starts the policy off from [DefaultPolicy] if there isn't one.
+func (s *Solution) Set(name, value string) error {
+	switch {
+	case name == "workers":
+		workers, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("bad value %q for option %s: %w", value, name, err)
+		}
+
+		s.Workers = workers
+
+		return nil
+	case slices.Contains(policyOptions[:], name):
+		if s.Policy == nil {
+			policy := DefaultPolicy()
+			s.Policy = &policy
+		}
+
+		return s.Policy.Set(name, value)
+	default:
+		return fmt.Errorf("unknown option %q, expected one of %s or workers", name, strings.Join(policyOptions[:], ", "))
+	}
+}
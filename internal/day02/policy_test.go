@@ -0,0 +1,368 @@
+package day02
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/test"
+)
+
+// safeDampenedBruteForce tries every way of removing up to removals levels from
+// the report. It's the reference SafeDampened is checked against.
+func safeDampenedBruteForce(policy SafetyPolicy, r Report, removals int) bool {
+	if policy.Safe(r) {
+		return true
+	}
+
+	if removals == 0 {
+		return false
+	}
+
+	for i := range r {
+		removed := append(append(Report{}, r[:i]...), r[i+1:]...)
+		if safeDampenedBruteForce(policy, removed, removals-1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestParseDirection(t *testing.T) {
+	for _, direction := range []Direction{DirectionAny, DirectionIncreasing, DirectionDecreasing} {
+		got, err := ParseDirection(direction.String())
+		test.Ok(t, err)
+		test.Equal(t, got, direction)
+	}
+
+	_, err := ParseDirection("sideways")
+	test.Err(t, err)
+}
+
+func TestSafetyPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string       // Name of the test case
+		policy  SafetyPolicy // The policy under test
+		wantErr bool         // Whether we want an error
+	}{
+		{name: "default", policy: DefaultPolicy()},
+		{name: "equal steps", policy: SafetyPolicy{MinStep: 2, MaxStep: 2}},
+		{name: "negative min", policy: SafetyPolicy{MinStep: -1, MaxStep: 3}, wantErr: true},
+		{name: "max below min", policy: SafetyPolicy{MinStep: 3, MaxStep: 1}, wantErr: true},
+		{name: "negative removals", policy: SafetyPolicy{MinStep: 1, MaxStep: 3, Removals: -1}, wantErr: true},
+		{name: "bad direction", policy: SafetyPolicy{MinStep: 1, MaxStep: 3, Direction: Direction(9)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.WantErr(t, tt.policy.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestSafetyPolicy(t *testing.T) {
+	tests := []struct {
+		name     string       // Name of the test case
+		policy   SafetyPolicy // The policy under test
+		report   Report       // The report under test
+		safe     bool         // Whether it's safe without the dampener
+		dampened bool         // Whether it's safe with the dampener
+	}{
+		{
+			name:     "default example 1",
+			policy:   DefaultPolicy(),
+			report:   Report{7, 6, 4, 2, 1},
+			safe:     true,
+			dampened: true,
+		},
+		{
+			name:     "default example 4",
+			policy:   DefaultPolicy(),
+			report:   Report{1, 3, 2, 4, 5},
+			safe:     false,
+			dampened: true,
+		},
+		{
+			name:     "looser max step",
+			policy:   SafetyPolicy{MinStep: 1, MaxStep: 5, Removals: 1},
+			report:   Report{1, 2, 7, 8, 9},
+			safe:     true,
+			dampened: true,
+		},
+		{
+			name:     "tighter max step",
+			policy:   SafetyPolicy{MinStep: 1, MaxStep: 1, Removals: 1},
+			report:   Report{1, 2, 3, 5, 6},
+			safe:     false,
+			dampened: false,
+		},
+		{
+			name:     "plateaus allowed",
+			policy:   SafetyPolicy{MinStep: 1, MaxStep: 3, Removals: 1, AllowPlateaus: true},
+			report:   Report{8, 6, 4, 4, 1},
+			safe:     true,
+			dampened: true,
+		},
+		{
+			name:     "plateaus still need a direction",
+			policy:   SafetyPolicy{MinStep: 1, MaxStep: 3, AllowPlateaus: true},
+			report:   Report{1, 1, 2, 1},
+			safe:     false,
+			dampened: false,
+		},
+		{
+			name:     "increasing only",
+			policy:   SafetyPolicy{MinStep: 1, MaxStep: 3, Removals: 1, Direction: DirectionIncreasing},
+			report:   Report{7, 6, 4, 2, 1},
+			safe:     false,
+			dampened: false,
+		},
+		{
+			name:     "decreasing only",
+			policy:   SafetyPolicy{MinStep: 1, MaxStep: 3, Removals: 1, Direction: DirectionDecreasing},
+			report:   Report{7, 6, 4, 2, 1},
+			safe:     true,
+			dampened: true,
+		},
+		{
+			name:     "two removals",
+			policy:   SafetyPolicy{MinStep: 1, MaxStep: 3, Removals: 2},
+			report:   Report{1, 9, 2, 9, 3},
+			safe:     false,
+			dampened: true,
+		},
+		{
+			name:     "no removals",
+			policy:   SafetyPolicy{MinStep: 1, MaxStep: 3, Removals: 0},
+			report:   Report{1, 3, 2, 4, 5},
+			safe:     false,
+			dampened: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.Equal(t, tt.policy.Safe(tt.report), tt.safe)             // Safe mismatch
+			test.Equal(t, tt.policy.SafeDampened(tt.report), tt.dampened) // SafeDampened mismatch
+		})
+	}
+}
+
+func TestSafeDampenedMatchesBruteForce(t *testing.T) {
+	policies := []SafetyPolicy{
+		DefaultPolicy(),
+		{MinStep: 1, MaxStep: 3, Removals: 2},
+		{MinStep: 1, MaxStep: 3, Removals: 3, AllowPlateaus: true},
+		{MinStep: 0, MaxStep: 2, Removals: 2, Direction: DirectionIncreasing},
+		{MinStep: 2, MaxStep: 4, Removals: 1, Direction: DirectionDecreasing, AllowPlateaus: true},
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 2000 {
+		report := make(Report, r.IntN(8))
+		for i := range report {
+			report[i] = r.Int32N(12)
+		}
+
+		for _, policy := range policies {
+			want := safeDampenedBruteForce(policy, report, policy.Removals)
+			if got := policy.SafeDampened(report); got != want {
+				t.Fatalf("%+v.SafeDampened(%v) = %v, brute force says %v", policy, report, got, want)
+			}
+		}
+	}
+}
+
+func TestSolutionSet(t *testing.T) {
+	tests := []struct {
+		name    string       // Name of the test case
+		options []aoc.Option // Options to set, in order
+		want    SafetyPolicy // Expected policy afterwards
+		wantErr bool         // Whether we want an error
+	}{
+		{
+			name:    "max step",
+			options: []aoc.Option{{Name: "max-step", Value: "5"}},
+			want:    SafetyPolicy{MinStep: 1, MaxStep: 5, Removals: 1},
+		},
+		{
+			name: "everything",
+			options: []aoc.Option{
+				{Name: "min-step", Value: "0"},
+				{Name: "max-step", Value: "2"},
+				{Name: "removals", Value: "3"},
+				{Name: "plateaus", Value: "true"},
+				{Name: "direction", Value: "decreasing"},
+			},
+			want: SafetyPolicy{MinStep: 0, MaxStep: 2, Removals: 3, AllowPlateaus: true, Direction: DirectionDecreasing},
+		},
+		{
+			name: "all zero",
+			options: []aoc.Option{
+				{Name: "min-step", Value: "0"},
+				{Name: "max-step", Value: "0"},
+				{Name: "removals", Value: "0"},
+				{Name: "plateaus", Value: "false"},
+				{Name: "direction", Value: "any"},
+			},
+			want: SafetyPolicy{},
+		},
+		{
+			name:    "unknown option",
+			options: []aoc.Option{{Name: "colour", Value: "red"}},
+			wantErr: true,
+		},
+		{
+			name:    "bad number",
+			options: []aoc.Option{{Name: "max-step", Value: "lots"}},
+			wantErr: true,
+		},
+		{
+			name:    "bad bool",
+			options: []aoc.Option{{Name: "plateaus", Value: "sometimes"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solution := &Solution{}
+
+			var err error
+			for _, option := range tt.options {
+				if err = solution.Set(option.Name, option.Value); err != nil {
+					break
+				}
+			}
+
+			test.WantErr(t, err, tt.wantErr)
+			if !tt.wantErr {
+				test.Equal(t, *solution.Policy, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafetyPolicySet(t *testing.T) {
+	policy := DefaultPolicy()
+	test.Ok(t, policy.Set("max-step", "5"))
+	test.Equal(t, policy, SafetyPolicy{MinStep: 1, MaxStep: 5, Removals: 1})
+
+	// Reaching the zero value mustn't reset the rules already set
+	test.Ok(t, policy.Set("min-step", "0"))
+	test.Ok(t, policy.Set("removals", "0"))
+	test.Ok(t, policy.Set("max-step", "0"))
+	test.Equal(t, policy, SafetyPolicy{})
+
+	test.Ok(t, policy.Set("plateaus", "true"))
+	test.Equal(t, policy, SafetyPolicy{AllowPlateaus: true})
+
+	// workers belongs to the Solution, not the rules
+	test.Err(t, policy.Set("workers", "3"))
+	test.Err(t, policy.Set("direction", "sideways"))
+}
+
+func TestSolutionPolicy(t *testing.T) {
+	solution := &Solution{Policy: &SafetyPolicy{MinStep: 1, MaxStep: 5, Removals: 1}}
+	test.Ok(t, solution.Parse(testInput))
+
+	part1, err := solution.Part1()
+	test.Ok(t, err)
+	test.Equal(t, part1, aoc.Answer("4")) // 1 2 7 8 9 and 9 7 6 2 1 are now safe
+
+	part2, err := solution.Part2()
+	test.Ok(t, err)
+	test.Equal(t, part2, aoc.Answer("6")) // And so is everything else
+
+	solution.Policy = &SafetyPolicy{MinStep: 3, MaxStep: 1}
+
+	_, err = solution.Part1()
+	test.Err(t, err)
+
+	_, err = solution.Part2()
+	test.Err(t, err)
+}
+
+func TestSolutionZeroPolicy(t *testing.T) {
+	// Every rule set to zero only allows levels that never change, it isn't the puzzle's
+	solution := &Solution{}
+	test.Ok(t, solution.Set("min-step", "0"))
+	test.Ok(t, solution.Set("max-step", "0"))
+	test.Ok(t, solution.Set("removals", "0"))
+	test.Ok(t, solution.Parse("5 5 5\n5 5 5\n1 2 3\n"))
+
+	part1, err := solution.Part1()
+	test.Ok(t, err)
+	test.Equal(t, part1, aoc.Answer("2"))
+
+	part2, err := solution.Part2()
+	test.Ok(t, err)
+	test.Equal(t, part2, aoc.Answer("2"))
+}
+
+func TestMinRemovals(t *testing.T) {
+	tests := []struct {
+		name   string       // Name of the test case
+		policy SafetyPolicy // The policy under test
+		report Report       // The report under test
+		want   []int        // Expected indices to remove
+	}{
+		{name: "empty", policy: DefaultPolicy(), report: Report{}, want: nil},
+		{name: "safe", policy: DefaultPolicy(), report: Report{7, 6, 4, 2, 1}, want: []int{}},
+		{name: "one", policy: DefaultPolicy(), report: Report{1, 3, 2, 4, 5}, want: []int{2}},
+		{name: "spike", policy: DefaultPolicy(), report: Report{1, 2, 9, 3, 4}, want: []int{2}},
+		{name: "two", policy: DefaultPolicy(), report: Report{1, 9, 2, 9, 3}, want: []int{1, 3}},
+		{name: "all but one", policy: DefaultPolicy(), report: Report{5, 5, 5, 5}, want: []int{1, 2, 3}},
+		{
+			name:   "required direction",
+			policy: SafetyPolicy{MinStep: 1, MaxStep: 3, Direction: DirectionIncreasing},
+			report: Report{9, 7, 6, 8, 1},
+			want:   []int{0, 2, 4}, // Keeping 6 8 would do just as well as 7 8
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.DeepEqual(t, tt.policy.MinRemovals(tt.report), tt.want)
+		})
+	}
+}
+
+func TestMinRemovalsMatchesBruteForce(t *testing.T) {
+	policies := []SafetyPolicy{
+		DefaultPolicy(),
+		{MinStep: 1, MaxStep: 3, AllowPlateaus: true},
+		{MinStep: 0, MaxStep: 2, Direction: DirectionIncreasing},
+		{MinStep: 2, MaxStep: 4, Direction: DirectionDecreasing},
+	}
+
+	r := rand.New(rand.NewPCG(5, 6))
+	for range 500 {
+		report := make(Report, r.IntN(7))
+		for i := range report {
+			report[i] = r.Int32N(10)
+		}
+
+		for _, policy := range policies {
+			remove := policy.MinRemovals(report)
+
+			// Removing those levels must leave a safe report
+			var kept Report
+			for i, level := range report {
+				if !slices.Contains(remove, i) {
+					kept = append(kept, level)
+				}
+			}
+			if !policy.Safe(kept) {
+				t.Fatalf("%+v: removing %v from %v leaves unsafe %v", policy, remove, report, kept)
+			}
+
+			// And nothing smaller will do
+			if len(remove) > 0 && safeDampenedBruteForce(policy, report, len(remove)-1) {
+				t.Fatalf("%+v: removing %v from %v is more than needed", policy, remove, report)
+			}
+		}
+	}
+}
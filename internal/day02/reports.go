@@ -0,0 +1,109 @@
+package day02
+
+import (
+	"fmt"
+	"iter"
+	"math"
+
+	"github.com/FollowTheProcess/aoc2024/internal/parse"
+)
+
+// reportSet is a list of reports stored flat: every level of every report back to
+// back in one slice, with the offset each report starts at in another.
+//
+// It takes two allocations however many reports there are, and Reports are views
+// straight into it.
+type reportSet struct {
+	levels  []int32 // Every level of every report
+	offsets []int   // Report i is levels[offsets[i]:offsets[i+1]], starting with 0
+}
+
+// Len returns the number of reports.
+func (s reportSet) Len() int {
+	return max(len(s.offsets)-1, 0)
+}
+
+// At returns report i, a view into the set that must not be modified.
+func (s reportSet) At(i int) Report {
+	start, end := s.offsets[i], s.offsets[i+1]
+
+	// Cap it so an append can never write over the next report
+	return Report(s.levels[start:end:end])
+}
+
+// All returns an iterator over the reports in order.
+func (s reportSet) All() iter.Seq[Report] {
+	return func(yield func(Report) bool) {
+		for i := range s.Len() {
+			if !yield(s.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the reports from start up to but not including end, sharing the
+// same storage.
+func (s reportSet) Slice(start, end int) reportSet {
+	return reportSet{levels: s.levels, offsets: s.offsets[start : end+1]}
+}
+
+// countLevels returns upper bounds on the number of reports and levels in
+// input, by counting lines and runs of non blank bytes.
+func countLevels[T ~string | ~[]byte](input T) (lines, levels int) {
+	inLevel := false
+	for i := range len(input) {
+		switch b := input[i]; {
+		case b == '\n':
+			lines++
+			inLevel = false
+		case parse.IsBlank(b):
+			inLevel = false
+		case !inLevel:
+			levels++
+			inLevel = true
+		}
+	}
+
+	// The last line may not end in a newline
+	return lines + 1, levels
+}
+
+// appendLevels parses the levels on a single line of input, appending them to
+// dst. Levels are base 10 int32s separated by any run of spaces, tabs or carriage
+// returns. It doesn't allocate beyond growing dst.
+func appendLevels[T ~string | ~[]byte](dst []int32, line T, lineNo int) ([]int32, error) {
+	i := 0
+	for {
+		for i < len(line) && parse.IsBlank(line[i]) {
+			i++
+		}
+
+		if i == len(line) {
+			return dst, nil
+		}
+
+		start := i
+		for i < len(line) && !parse.IsBlank(line[i]) {
+			i++
+		}
+
+		level, ok := parseLevel(line[start:i])
+		if !ok {
+			return dst, fmt.Errorf("bad level (%q in %q) on line %d", line[start:i], line, lineNo)
+		}
+
+		dst = append(dst, level)
+	}
+}
+
+// parseLevel parses a single optionally signed base 10 level, ok is false if raw
+// isn't a valid integer or doesn't fit in the int32 levels are stored as.
+func parseLevel[T ~string | ~[]byte](raw T) (level int32, ok bool) {
+	n, ok := parse.Int(raw)
+	if !ok || n > math.MaxInt32 || n < math.MinInt32 {
+		return 0, false
+	}
+
+	return int32(n), true
+}
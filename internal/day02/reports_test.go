@@ -0,0 +1,163 @@
+package day02
+
+import (
+	"bufio"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/FollowTheProcess/test"
+)
+
+// flatten stores reports in a reportSet.
+func flatten(reports []Report) reportSet {
+	set := reportSet{offsets: []int{0}}
+	for _, report := range reports {
+		set.levels = append(set.levels, report...)
+		set.offsets = append(set.offsets, len(set.levels))
+	}
+
+	return set
+}
+
+// format renders reports as puzzle input.
+func format(reports reportSet) string {
+	s := &strings.Builder{}
+	for report := range reports.All() {
+		s.WriteString(report.String())
+		s.WriteByte('\n')
+	}
+
+	return s.String()
+}
+
+func TestParseWhitespace(t *testing.T) {
+	tests := []struct {
+		name  string   // Name of the test case
+		input string   // Raw input
+		want  []Report // Expected reports
+	}{
+		{name: "empty", input: "", want: nil},
+		{name: "no trailing newline", input: "1 2 3\n4 5", want: []Report{{1, 2, 3}, {4, 5}}},
+		{name: "doubled spaces", input: "1  2   3\n", want: []Report{{1, 2, 3}}},
+		{name: "tabs", input: "1\t2 \t3\n", want: []Report{{1, 2, 3}}},
+		{name: "crlf", input: "1 2 3\r\n4 5\r\n", want: []Report{{1, 2, 3}, {4, 5}}},
+		{name: "blank lines", input: "\n\n1 2\n  \n\n3 4\n\n", want: []Report{{1, 2}, {3, 4}}},
+		{name: "leading and trailing blanks", input: "  1 2  \n", want: []Report{{1, 2}}},
+		{name: "signs", input: "-1 +2 -2147483648 2147483647\n", want: []Report{{-1, 2, -2147483648, 2147483647}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := parseInput(tt.input)
+			test.Ok(t, err)
+			test.DeepEqual(t, slices.Collect(set.All()), tt.want)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string // Name of the test case
+		input string // Raw input
+		want  string // Expected error message
+	}{
+		{name: "letters", input: "1 2\n3 x 4\n", want: `bad level ("x" in "3 x 4") on line 2`},
+		{name: "sign only", input: "1 - 2\n", want: `bad level ("-" in "1 - 2") on line 1`},
+		{name: "too big", input: "2147483648\n", want: `bad level ("2147483648" in "2147483648") on line 1`},
+		{name: "too small", input: "-2147483649\n", want: `bad level ("-2147483649" in "-2147483649") on line 1`},
+		{name: "huge", input: "99999999999999999999999\n", want: `bad level ("99999999999999999999999" in "99999999999999999999999") on line 1`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseInput(tt.input)
+			test.Err(t, err)
+			test.Equal(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestReportSet(t *testing.T) {
+	set := flatten([]Report{{1, 2, 3}, {4, 5}, {6}})
+
+	test.Equal(t, set.Len(), 3)
+	test.EqualFunc(t, set.At(1), Report{4, 5}, slices.Equal)
+
+	// Appending to a view must never clobber the next report
+	grown := append(set.At(0), 99)
+	test.EqualFunc(t, grown, Report{1, 2, 3, 99}, slices.Equal)
+	test.EqualFunc(t, set.At(1), Report{4, 5}, slices.Equal)
+
+	middle := set.Slice(1, 3)
+	test.Equal(t, middle.Len(), 2)
+	test.DeepEqual(t, slices.Collect(middle.All()), []Report{{4, 5}, {6}})
+
+	test.Equal(t, reportSet{}.Len(), 0)
+}
+
+func TestParseAllocations(t *testing.T) {
+	for _, n := range []int{10, 1000} {
+		raw := format(randomReports(n, 1))
+		allocs := testing.AllocsPerRun(10, func() {
+			if _, err := parseInput(raw); err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		// One for the levels, one for the offsets
+		if allocs != 2 {
+			t.Errorf("parsing %d reports took %v allocations, want 2", n, allocs)
+		}
+	}
+}
+
+// parseInputSplit is the original line by line parser with a slice per report,
+// kept to compare allocations against.
+func parseInputSplit(input string) ([]Report, error) {
+	scanner := bufio.NewScanner(strings.NewReader(strings.TrimSpace(input)))
+
+	var reports []Report
+	for scanner.Scan() {
+		rawLevels := strings.Split(scanner.Text(), " ")
+		report := make(Report, 0, len(rawLevels))
+		for _, rawLevel := range rawLevels {
+			level, err := strconv.Atoi(rawLevel)
+			if err != nil {
+				return nil, err
+			}
+			report = append(report, int32(level))
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, n := range []int{1_000, 100_000, 1_000_000} {
+		raw := format(randomReports(n, 1))
+
+		b.Run(fmt.Sprintf("flat/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(raw)))
+			for range b.N {
+				if _, err := parseInput(raw); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("split/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(raw)))
+			for range b.N {
+				if _, err := parseInputSplit(raw); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package day02
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+	"time"
+)
+
+// Reports returns an iterator over the reports read from r one line at a time, so
+// reports can be checked while the input is still arriving.
+//
+// Lines are parsed the same way as the puzzle input and blank lines are skipped.
+// Iteration stops after yielding the first error, from either a bad line or r
+// itself. To avoid allocating, each Report reuses the same storage so it is only
+// valid until the next one is yielded, clone it to keep it.
+func Reports(r io.Reader) iter.Seq2[Report, error] {
+	return func(yield func(Report, error) bool) {
+		scanner := bufio.NewScanner(r)
+
+		var (
+			levels []int32
+			err    error
+		)
+
+		lineNo := 0
+		for scanner.Scan() {
+			lineNo++
+
+			levels, err = appendLevels(levels[:0], scanner.Bytes(), lineNo)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if len(levels) == 0 {
+				// Blank line
+				continue
+			}
+
+			if !yield(Report(levels), nil) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			yield(nil, fmt.Errorf("could not read line %d: %w", lineNo+1, err))
+		}
+	}
+}
+
+// Monitor classifies reports as they stream in, printing running totals.
+type Monitor struct {
+	Now      func() time.Time // Returns the current time, nil means [time.Now]
+	Policy   *SafetyPolicy    // The rules for a safe report, nil means the puzzle's
+	Interval time.Duration    // Minimum time between running totals, less than 1 means only at the end
+}
+
+// Run reads reports from r until EOF, writing the running totals to w whenever a
+// report arrives at least m.Interval after the totals were last written, and the
+// final totals once r is exhausted.
+//
+// Totals are only written as reports arrive, so an idle stream (e.g. tail -f of a
+// quiet log) stays quiet until there is something new to say.
+func (m Monitor) Run(r io.Reader, w io.Writer) error {
+	policy := orDefault(m.Policy)
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	now := m.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	var t tally
+	last := now()
+	for report, err := range Reports(r) {
+		if err != nil {
+			return err
+		}
+
+		t.add(policy.Classify(report))
+
+		if m.Interval > 0 && now().Sub(last) >= m.Interval {
+			if _, err := fmt.Fprintln(w, t); err != nil {
+				return err
+			}
+			last = now()
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "Final: %s\n", t)
+
+	return err
+}
+
+// String implements [fmt.Stringer] for a tally, summarising the totals on one line.
+func (t tally) String() string {
+	return fmt.Sprintf(
+		"%d reports, %d safe, %d safe with the dampener, %d unsafe",
+		t.safe+t.dampened+t.unsafe,
+		t.safe,
+		t.safe+t.dampened,
+		t.unsafe,
+	)
+}
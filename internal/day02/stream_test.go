@@ -0,0 +1,115 @@
+package day02
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/FollowTheProcess/test"
+)
+
+func TestReports(t *testing.T) {
+	var got []Report
+	for report, err := range Reports(strings.NewReader("7 6 4 2 1\r\n\n1  2 7 8 9\n9 7\t6 2 1")) {
+		test.Ok(t, err)
+		got = append(got, slices.Clone(report))
+	}
+
+	test.DeepEqual(t, got, []Report{{7, 6, 4, 2, 1}, {1, 2, 7, 8, 9}, {9, 7, 6, 2, 1}})
+}
+
+func TestReportsErrors(t *testing.T) {
+	t.Run("bad line", func(t *testing.T) {
+		var (
+			reports int
+			errs    []error
+		)
+		for report, err := range Reports(strings.NewReader("1 2 3\n4 x 6\n7 8 9\n")) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			test.True(t, report != nil)
+			reports++
+		}
+
+		test.Equal(t, reports, 1)
+		test.Equal(t, len(errs), 1)
+		test.Equal(t, errs[0].Error(), `bad level ("x" in "4 x 6") on line 2`)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		var last error
+		for _, err := range Reports(iotest.ErrReader(iotest.ErrTimeout)) {
+			last = err
+		}
+
+		test.Err(t, last)
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		reports := 0
+		for range Reports(strings.NewReader("1 2\n3 4\n5 6\n")) {
+			reports++
+			break
+		}
+
+		test.Equal(t, reports, 1)
+	})
+}
+
+func TestReportsMatchesParse(t *testing.T) {
+	set, err := parseInput(input)
+	test.Ok(t, err)
+
+	i := 0
+	for report, err := range Reports(strings.NewReader(input)) {
+		test.Ok(t, err)
+		test.EqualFunc(t, report, set.At(i), slices.Equal)
+		i++
+	}
+
+	test.Equal(t, i, set.Len())
+}
+
+func TestMonitor(t *testing.T) {
+	// Every call to now moves the clock on a second
+	clock := time.Date(2024, time.December, 2, 0, 0, 0, 0, time.UTC)
+	now := func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+
+	out := &bytes.Buffer{}
+	monitor := Monitor{Now: now, Interval: 2 * time.Second}
+	test.Ok(t, monitor.Run(strings.NewReader(testInput), out))
+
+	want := `2 reports, 1 safe, 1 safe with the dampener, 1 unsafe
+4 reports, 1 safe, 2 safe with the dampener, 2 unsafe
+6 reports, 2 safe, 4 safe with the dampener, 2 unsafe
+Final: 6 reports, 2 safe, 4 safe with the dampener, 2 unsafe
+`
+	test.Diff(t, out.String(), want)
+}
+
+func TestMonitorOnlyAtEnd(t *testing.T) {
+	out := &bytes.Buffer{}
+	test.Ok(t, Monitor{}.Run(strings.NewReader(input), out))
+	test.Equal(t, out.String(), "Final: 1000 reports, 598 safe, 634 safe with the dampener, 366 unsafe\n")
+}
+
+func TestMonitorErrors(t *testing.T) {
+	out := &bytes.Buffer{}
+	test.Err(t, Monitor{}.Run(strings.NewReader("1 2 3\nnope\n"), out))
+	test.Err(t, Monitor{Policy: &SafetyPolicy{MinStep: 3, MaxStep: 1}}.Run(strings.NewReader(testInput), out))
+}
+
+func TestMonitorZeroPolicy(t *testing.T) {
+	// Every rule set to zero is a real policy, not the puzzle's
+	out := &bytes.Buffer{}
+	test.Ok(t, Monitor{Policy: &SafetyPolicy{}}.Run(strings.NewReader("5 5 5\n5 5 5\n1 2 3\n"), out))
+	test.Equal(t, out.String(), "Final: 3 reports, 2 safe, 2 safe with the dampener, 1 unsafe\n")
+}
@@ -0,0 +1,145 @@
+package day02
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Rule is one of the rules a pair of adjacent levels can break.
+type Rule int
+
+const (
+	// RuleDirection is a step going the opposite way to the rest of the report,
+	// or to the policy's required direction.
+	RuleDirection Rule = iota
+
+	// RulePlateau is a step where the level doesn't change at all.
+	RulePlateau
+
+	// RuleTooSmall is a step smaller than the policy's minimum.
+	RuleTooSmall
+
+	// RuleTooLarge is a step larger than the policy's maximum.
+	RuleTooLarge
+)
+
+// rules is every Rule, in the order summaries list them.
+var rules = [...]Rule{RuleDirection, RulePlateau, RuleTooSmall, RuleTooLarge}
+
+// String implements [fmt.Stringer] for a Rule.
+func (r Rule) String() string {
+	switch r {
+	case RuleDirection:
+		return "wrong direction"
+	case RulePlateau:
+		return "no change"
+	case RuleTooSmall:
+		return "step too small"
+	case RuleTooLarge:
+		return "step too large"
+	default:
+		return fmt.Sprintf("Rule(%d)", int(r))
+	}
+}
+
+// MarshalText implements [encoding.TextMarshaler] for a Rule.
+func (r Rule) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// Violation is a pair of adjacent levels in a report breaking one of the rules.
+type Violation struct {
+	Pair  [2]int `json:"pair"`  // The two offending levels
+	Index int    `json:"index"` // The 0 based index in the report of the first level
+	Rule  Rule   `json:"rule"`  // The rule that was broken
+}
+
+// String describes the violation the way the puzzle text does, e.g.
+// "2 7 is an increase of 5: step too large". Levels are numbered from 1.
+func (v Violation) String() string {
+	step := v.Pair[1] - v.Pair[0]
+
+	var change string
+	switch {
+	case step > 0:
+		change = "an increase of " + strconv.Itoa(step)
+	case step < 0:
+		change = "a decrease of " + strconv.Itoa(-step)
+	default:
+		change = "neither an increase or a decrease"
+	}
+
+	return fmt.Sprintf("levels %d and %d: %d %d is %s: %s", v.Index+1, v.Index+2, v.Pair[0], v.Pair[1], change, v.Rule)
+}
+
+// Violations returns every breach of the puzzle's rules in the report, in order,
+// it's empty if and only if the report is safe.
+func (r Report) Violations() []Violation {
+	return DefaultPolicy().Violations(r)
+}
+
+// Violations returns every breach of the policy in the report without the problem
+// dampener, in order. It's empty if and only if p.Safe(r).
+//
+// Unless the policy requires a direction, the report's first change of level
+// decides which way it's heading, just as the puzzle judges "1 3 2 4 5" to be
+// increasing until 3 2 decreases. A step can break more than one rule.
+func (p SafetyPolicy) Violations(r Report) []Violation {
+	sign := 0
+	switch p.Direction {
+	case DirectionIncreasing:
+		sign = 1
+	case DirectionDecreasing:
+		sign = -1
+	case DirectionAny:
+		// Decided by the first change
+	}
+
+	var violations []Violation
+	for i := 1; i < len(r); i++ {
+		found := func(rule Rule) {
+			violations = append(violations, Violation{Pair: [2]int{int(r[i-1]), int(r[i])}, Index: i - 1, Rule: rule})
+		}
+
+		step := r.step(i-1, i)
+		if step == 0 {
+			if !p.allowed(0) {
+				found(RulePlateau)
+			}
+			continue
+		}
+
+		if sign == 0 {
+			sign = 1
+			if step < 0 {
+				sign = -1
+			}
+		}
+
+		if step*sign < 0 {
+			found(RuleDirection)
+		}
+
+		size := max(step, -step)
+		if size < p.MinStep {
+			found(RuleTooSmall)
+		}
+
+		if size > p.MaxStep {
+			found(RuleTooLarge)
+		}
+	}
+
+	return violations
+}
+
+// String renders the report's levels separated by spaces, as in the puzzle input.
+func (r Report) String() string {
+	levels := make([]string, 0, len(r))
+	for _, level := range r {
+		levels = append(levels, strconv.Itoa(int(level)))
+	}
+
+	return strings.Join(levels, " ")
+}
@@ -0,0 +1,200 @@
+package day02
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/FollowTheProcess/test"
+)
+
+func TestViolations(t *testing.T) {
+	tests := []struct {
+		name   string       // Name of the test case
+		policy SafetyPolicy // The policy the report is checked against
+		report Report       // The report under test
+		want   []Violation  // Expected violations
+	}{
+		{
+			name:   "safe",
+			policy: DefaultPolicy(),
+			report: Report{7, 6, 4, 2, 1},
+			want:   nil,
+		},
+		{
+			name:   "too large",
+			policy: DefaultPolicy(),
+			report: Report{1, 2, 7, 8, 9},
+			want:   []Violation{{Pair: [2]int{2, 7}, Index: 1, Rule: RuleTooLarge}},
+		},
+		{
+			name:   "direction",
+			policy: DefaultPolicy(),
+			report: Report{1, 3, 2, 4, 5},
+			want:   []Violation{{Pair: [2]int{3, 2}, Index: 1, Rule: RuleDirection}},
+		},
+		{
+			name:   "plateau",
+			policy: DefaultPolicy(),
+			report: Report{8, 6, 4, 4, 1},
+			want:   []Violation{{Pair: [2]int{4, 4}, Index: 2, Rule: RulePlateau}},
+		},
+		{
+			name:   "leading plateau doesn't decide direction",
+			policy: DefaultPolicy(),
+			report: Report{4, 4, 3, 2},
+			want:   []Violation{{Pair: [2]int{4, 4}, Index: 0, Rule: RulePlateau}},
+		},
+		{
+			name:   "wrong direction and too large",
+			policy: DefaultPolicy(),
+			report: Report{1, 2, 3, 9, 2},
+			want: []Violation{
+				{Pair: [2]int{3, 9}, Index: 2, Rule: RuleTooLarge},
+				{Pair: [2]int{9, 2}, Index: 3, Rule: RuleDirection},
+				{Pair: [2]int{9, 2}, Index: 3, Rule: RuleTooLarge},
+			},
+		},
+		{
+			name:   "too small",
+			policy: SafetyPolicy{MinStep: 2, MaxStep: 3},
+			report: Report{1, 3, 4},
+			want:   []Violation{{Pair: [2]int{3, 4}, Index: 1, Rule: RuleTooSmall}},
+		},
+		{
+			name:   "plateaus allowed",
+			policy: SafetyPolicy{MinStep: 1, MaxStep: 3, AllowPlateaus: true},
+			report: Report{8, 6, 4, 4, 1},
+			want:   nil,
+		},
+		{
+			name:   "required direction",
+			policy: SafetyPolicy{MinStep: 1, MaxStep: 3, Direction: DirectionIncreasing},
+			report: Report{3, 2, 1},
+			want: []Violation{
+				{Pair: [2]int{3, 2}, Index: 0, Rule: RuleDirection},
+				{Pair: [2]int{2, 1}, Index: 1, Rule: RuleDirection},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.DeepEqual(t, tt.policy.Violations(tt.report), tt.want)
+		})
+	}
+}
+
+func TestViolationsMatchSafe(t *testing.T) {
+	policies := []SafetyPolicy{
+		DefaultPolicy(),
+		{MinStep: 0, MaxStep: 2},
+		{MinStep: 2, MaxStep: 4, AllowPlateaus: true},
+		{MinStep: 1, MaxStep: 3, Direction: DirectionIncreasing},
+		{MinStep: 1, MaxStep: 3, Direction: DirectionDecreasing, AllowPlateaus: true},
+	}
+
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 2000 {
+		report := make(Report, r.IntN(6))
+		for i := range report {
+			report[i] = r.Int32N(8)
+		}
+
+		for _, policy := range policies {
+			if safe, violations := policy.Safe(report), policy.Violations(report); safe != (len(violations) == 0) {
+				t.Fatalf("%+v: report %v has Safe() = %v but violations %v", policy, report, safe, violations)
+			}
+		}
+	}
+}
+
+func TestViolationString(t *testing.T) {
+	tests := []struct {
+		name      string    // Name of the test case
+		violation Violation // The violation under test
+		want      string    // Expected description
+	}{
+		{
+			name:      "increase",
+			violation: Violation{Pair: [2]int{2, 7}, Index: 1, Rule: RuleTooLarge},
+			want:      "levels 2 and 3: 2 7 is an increase of 5: step too large",
+		},
+		{
+			name:      "decrease",
+			violation: Violation{Pair: [2]int{3, 2}, Index: 1, Rule: RuleDirection},
+			want:      "levels 2 and 3: 3 2 is a decrease of 1: wrong direction",
+		},
+		{
+			name:      "plateau",
+			violation: Violation{Pair: [2]int{4, 4}, Index: 2, Rule: RulePlateau},
+			want:      "levels 3 and 4: 4 4 is neither an increase or a decrease: no change",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.Equal(t, tt.violation.String(), tt.want)
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	solution := &Solution{}
+	test.Ok(t, solution.Parse(testInput))
+
+	explanation, err := solution.Explain()
+	test.Ok(t, err)
+
+	want := `Report 2: 1 2 7 8 9 (unsafe)
+  levels 2 and 3: 2 7 is an increase of 5: step too large
+  fewest levels to remove: 1, 2
+Report 3: 9 7 6 2 1 (unsafe)
+  levels 3 and 4: 6 2 is a decrease of 4: step too large
+  fewest levels to remove: 4, 5
+Report 4: 1 3 2 4 5 (safe with the problem dampener)
+  levels 2 and 3: 3 2 is a decrease of 1: wrong direction
+  fewest levels to remove: 3
+Report 5: 8 6 4 4 1 (safe with the problem dampener)
+  levels 3 and 4: 4 4 is neither an increase or a decrease: no change
+  fewest levels to remove: 4
+
+Safe reports: 2 of 6
+Safe with the problem dampener: 4 of 6
+
+REASON           VIOLATIONS  REPORTS
+wrong direction  1           1
+no change        1           1
+step too small   0           0
+step too large   2           2
+
+REMOVALS  REPORTS  HISTOGRAM
+0         2        ########################################
+1         2        ########################################
+2         2        ########################################
+`
+	test.Diff(t, explanation.String(), want)
+
+	raw, err := json.Marshal(explanation)
+	test.Ok(t, err)
+
+	var decoded struct {
+		Unsafe []struct {
+			Violations []struct {
+				Rule string `json:"rule"`
+			} `json:"violations"`
+		} `json:"unsafe"`
+		Safe     int `json:"safe"`
+		Dampened int `json:"dampened"`
+	}
+	test.Ok(t, json.Unmarshal(raw, &decoded))
+
+	test.Equal(t, decoded.Safe, 2)
+	test.Equal(t, decoded.Dampened, 4)
+	test.Equal(t, len(decoded.Unsafe), 4)
+	test.Equal(t, decoded.Unsafe[0].Violations[0].Rule, "step too large")
+
+	solution.Policy = &SafetyPolicy{MinStep: 3, MaxStep: 1}
+	_, err = solution.Explain()
+	test.Err(t, err)
+}
@@ -0,0 +1,148 @@
+/*
+--- Day 3: Mull It Over ---
+
+"Our computers are having issues, so I have no idea if we have any Chief Historians in stock! You're welcome to check the warehouse, though,"
+says the mildly flustered shopkeeper at the North Pole Toboggan Rental Shop. The Historians head out to take a look.
+
+The shopkeeper turns to you. "Any chance you can see why our computers are having issues again?"
+
+The computer appears to be trying to run a program, but its memory (your puzzle input) is corrupted. All of the instructions have been jumbled up!
+
+It seems like the goal of the program is just to multiply some numbers. It does that with instructions like mul(X,Y), where X and Y are each 1-3 digit numbers.
+For instance, mul(44,46) multiplies 44 by 46 to get a result of 2024. Similarly, mul(123,4) would multiply 123 by 4.
+
+However, because the program's memory has been corrupted, there are also many invalid characters that should be ignored, even if they look like
+part of a mul instruction. Sequences like mul(4*, mul(6,9!, ?(12,34), or mul ( 2 , 4 ) do nothing.
+
+For example, consider the following section of corrupted memory:
+
+xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))
+
+Only the four highlighted sections are real mul instructions. Adding up the result of each instruction produces 161 (2*4 + 5*5 + 11*8 + 8*5).
+
+Scan the corrupted memory for uncorrupted mul instructions. What do you get if you add up all of the results of the multiplications?
+
+--- Part Two ---
+
+As you scan through the corrupted memory, you notice that some of the conditional statements are also still intact.
+If you handle some of the uncorrupted conditional statements in the program, you might be able to get an even more accurate result.
+
+There are two new instructions you'll need to handle:
+
+The do() instruction enables future mul instructions.
+The don't() instruction disables future mul instructions.
+
+Only the most recent do() or don't() instruction applies. At the beginning of the program, mul instructions are enabled.
+
+For example:
+
+xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))
+
+This corrupted memory is similar to the example from before, but this time the mul(5,5) and mul(11,8) instructions are disabled because there
+is a don't() instruction before them. The other mul instructions function normally, including the one at the end that gets re-enabled by a do() instruction.
+
+This time, the sum of the results is 48 (2*4 + 8*5).
+
+Handle the new instructions; what do you get if you add up all of the results of just the enabled multiplications?
+*/
+
+package day03
+
+import (
+	_ "embed"
+	"errors"
+	"slices"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+)
+
+//go:embed day03.txt
+var input string
+
+//go:embed answers.txt
+var answers string
+
+// Day returns the day 3 puzzle, ready to be registered with the runner.
+func Day() aoc.Day {
+	return aoc.Day{
+		Number:  3,
+		Input:   input,
+		Answers: answers,
+		New:     func() aoc.Solution { return &Solution{} },
+	}
+}
+
+// puzzle interprets the puzzle's own instructions.
+var puzzle = Puzzle()
+
+// Solution is the solution to day 3, it implements [aoc.Solution].
+type Solution struct {
+	tokens []Token // Every intact instruction in the corrupted memory
+	muls   []Mul   // Every mul instruction, enabled or not
+}
+
+// Parse parses the instructions out of the corrupted memory, lexing it once for
+// both parts.
+func (s *Solution) Parse(input string) error {
+	tokens := slices.Collect(puzzle.lex(input))
+
+	muls := allMuls(tokens)
+	if len(muls) == 0 {
+		return errors.New("no muls found")
+	}
+
+	s.tokens = tokens
+	s.muls = muls
+
+	return nil
+}
+
+// Part1 returns the sum of every mul instruction.
+func (s *Solution) Part1() (aoc.Answer, error) {
+	return aoc.Int(sumMuls(s.muls)), nil
+}
+
+// Part2 returns the sum of only the mul instructions enabled by do() and don't(),
+// by running the program.
+func (s *Solution) Part2() (aoc.Answer, error) {
+	m := NewMachine()
+	if err := puzzle.Exec(m, slices.Values(s.tokens)); err != nil {
+		return "", err
+	}
+
+	return aoc.Int(m.Acc), nil
+}
+
+// sumMuls performs every mul, returning the sum of the results.
+func sumMuls(muls []Mul) int {
+	sum := 0
+	for _, mul := range muls {
+		sum += mul.Do()
+	}
+
+	return sum
+}
+
+// Mul represents a multiply instruction.
+type Mul struct {
+	X     int // The left operand
+	Y     int // The right operand
+	Start int // The start position of this mul in the input string
+}
+
+// Do performs the multiplication, returning the answer.
+func (m Mul) Do() int {
+	return m.X * m.Y
+}
+
+// allMuls returns every mul in the tokens, enabled or not.
+func allMuls(tokens []Token) []Mul {
+	var muls []Mul
+	for _, token := range tokens {
+		if token.Op == OpMul {
+			muls = append(muls, token.Mul())
+		}
+	}
+
+	return muls
+}
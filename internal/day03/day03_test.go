@@ -0,0 +1,133 @@
+package day03
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/test"
+)
+
+const (
+	testInput                = "xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))"
+	testInputWithDosAndDonts = "xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))"
+)
+
+func TestLexAt(t *testing.T) {
+	tests := []struct {
+		name  string // Name of the test case
+		input string // The input to lex
+		want  Mul    // Expected mul instruction
+		ok    bool   // Whether input should be exactly one mul
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  Mul{},
+			ok:    false,
+		},
+		{
+			name:  "no muls",
+			input: "random words here but nothing we want",
+			want:  Mul{},
+			ok:    false,
+		},
+		{
+			name:  "valid",
+			input: "mul(5,3)",
+			want:  Mul{X: 5, Y: 3},
+			ok:    true,
+		},
+		{
+			name:  "3 digits",
+			input: "mul(555,333)",
+			want:  Mul{X: 555, Y: 333},
+			ok:    true,
+		},
+		{
+			name:  "invalid opening bracket",
+			input: "mul[5,3)",
+			want:  Mul{},
+			ok:    false,
+		},
+		{
+			name:  "invalid closing bracket",
+			input: "mul(5,3]",
+			want:  Mul{},
+			ok:    false,
+		},
+		{
+			name:  "no comma",
+			input: "mul(53)",
+			want:  Mul{},
+			ok:    false,
+		},
+		{
+			name:  "spaces",
+			input: "mul( 5 3 )",
+			want:  Mul{},
+			ok:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, ok := puzzle.lexAt(tt.input, 0)
+			ok = ok && token.Op == OpMul && token.End == len(tt.input)
+			test.Equal(t, ok, tt.ok)
+
+			if ok {
+				test.Equal(t, token.Mul(), tt.want)
+			}
+		})
+	}
+}
+
+func TestAllMuls(t *testing.T) {
+	got := allMuls(slices.Collect(puzzle.lex(testInput)))
+
+	want := []Mul{
+		{X: 2, Y: 4, Start: 1},
+		{X: 5, Y: 5, Start: 29},
+		{X: 11, Y: 8, Start: 53},
+		{X: 8, Y: 5, Start: 62},
+	}
+
+	test.EqualFunc(t, got, want, slices.Equal)
+}
+
+func TestPart1Example(t *testing.T) {
+	got := sumMuls(allMuls(slices.Collect(puzzle.lex(testInput))))
+	test.Equal(t, got, 161) // Wrong answer for part 1 example
+}
+
+func TestPart2Example(t *testing.T) {
+	m, err := Puzzle().Run(testInputWithDosAndDonts)
+	test.Ok(t, err)
+	test.Equal(t, m.Acc, 48) // Wrong answer for part 2 example
+}
+
+func TestSolution(t *testing.T) {
+	t.Run("part 1", func(t *testing.T) {
+		solution := &Solution{}
+		test.Ok(t, solution.Parse(testInput))
+
+		got, err := solution.Part1()
+		test.Ok(t, err)
+		test.Equal(t, got, aoc.Answer("161")) // Wrong answer for part 1 example
+	})
+
+	t.Run("part 2", func(t *testing.T) {
+		solution := &Solution{}
+		test.Ok(t, solution.Parse(testInputWithDosAndDonts))
+
+		got, err := solution.Part2()
+		test.Ok(t, err)
+		test.Equal(t, got, aoc.Answer("48")) // Wrong answer for part 2 example
+	})
+
+	t.Run("no muls", func(t *testing.T) {
+		solution := &Solution{}
+		test.Err(t, solution.Parse("mul[3,7]!@^do()_not_mul(32,64]"))
+	})
+}
@@ -0,0 +1,179 @@
+package day03
+
+import (
+	"iter"
+	"strings"
+)
+
+const (
+	// defaultOperandDigits is the most digits an operand may have if its Operands
+	// don't say, the puzzle's own limit.
+	defaultOperandDigits = 3
+
+	// maxOperandDigits is the most digits an operand can ever have without
+	// overflowing an int.
+	maxOperandDigits = 18
+)
+
+// Token is a single intact instruction found in the corrupted memory.
+type Token struct {
+	Args  [MaxArgs]int // The instruction's operands, only the first Arity are used
+	Op    Opcode       // Which registered instruction it is
+	Arity int          // How many operands it has
+	Start int          // Byte offset of the start of the instruction in the input
+	End   int          // Byte offset one past the end of the instruction
+}
+
+// Mul returns the mul instruction an OpMul token represents.
+func (t Token) Mul() Mul {
+	return Mul{X: t.Args[0], Y: t.Args[1], Start: t.Start}
+}
+
+// Grammar lexes the arguments of an instruction, everything between its brackets.
+type Grammar interface {
+	// Lex lexes the arguments starting at input[start], just after the opening
+	// bracket. It returns the operands, how many of them there were and the index
+	// just after the arguments, where the closing bracket must be. ok is false if
+	// the arguments are corrupted.
+	//
+	// The operands are returned by value so lexing never allocates.
+	Lex(input string, start int) (args [MaxArgs]int, n, end int, ok bool)
+}
+
+// GrammarFunc is an ordinary function used as a [Grammar].
+type GrammarFunc func(input string, start int) (args [MaxArgs]int, n, end int, ok bool)
+
+// Lex implements [Grammar] by calling f.
+func (f GrammarFunc) Lex(input string, start int) (args [MaxArgs]int, n, end int, ok bool) {
+	return f(input, start)
+}
+
+// Operands is the usual argument grammar: a fixed number of comma separated base 10
+// integers, e.g. the 2,4 of mul(2,4).
+type Operands struct {
+	Count     int  // How many operands there are, 0 for an instruction like do()
+	MaxDigits int  // The most digits each may have, 0 means 3 like the puzzle's
+	Signed    bool // Whether each may have a leading minus sign
+}
+
+// Lex implements [Grammar] for Operands.
+func (o Operands) Lex(input string, start int) (args [MaxArgs]int, n, end int, ok bool) {
+	digits := o.MaxDigits
+	if digits == 0 {
+		digits = defaultOperandDigits
+	}
+
+	i := start
+	for arg := range o.Count {
+		if arg > 0 {
+			if i >= len(input) || input[i] != ',' {
+				return args, 0, 0, false
+			}
+			i++
+		}
+
+		args[arg], i, ok = lexOperand(input, i, digits, o.Signed)
+		if !ok {
+			return args, 0, 0, false
+		}
+	}
+
+	return args, o.Count, i, true
+}
+
+// lex returns an iterator over every intact registered instruction in the corrupted
+// memory, in order, walking the input exactly once.
+//
+// Instructions are matched at the earliest position they can be and never overlap,
+// so anything that isn't exactly a registered name followed by arguments its
+// Grammar accepts in brackets is skipped over.
+func (in *Interpreter) lex(input string) iter.Seq[Token] {
+	return func(yield func(Token) bool) {
+		i := 0
+		for i < len(input) {
+			token, ok := in.lexAt(input, i)
+			if !ok {
+				i++
+				continue
+			}
+
+			if !yield(token) {
+				return
+			}
+
+			i = token.End
+		}
+	}
+}
+
+// lexAt tries to lex an instruction starting exactly at input[start].
+//
+// Names can't contain brackets, so at most one registered name followed by "(" can
+// match at any position.
+func (in *Interpreter) lexAt(input string, start int) (Token, bool) {
+	if start >= len(input) {
+		return Token{}, false
+	}
+
+	for _, op := range in.byFirstByte[input[start]] {
+		name := in.instructions[op].Name
+		i := start + len(name)
+		if !strings.HasPrefix(input[start:], name) || i >= len(input) || input[i] != '(' {
+			continue
+		}
+
+		return in.lexArgs(input, start, i+1, op)
+	}
+
+	return Token{}, false
+}
+
+// lexArgs lexes the arguments and closing bracket of the instruction op, whose name
+// starts at input[start], with its arguments starting at input[i].
+func (in *Interpreter) lexArgs(input string, start, i int, op Opcode) (Token, bool) {
+	token := Token{Op: op, Start: start}
+
+	if grammar := in.instructions[op].Args; grammar != nil {
+		args, arity, end, ok := grammar.Lex(input, i)
+		if !ok || arity < 0 || arity > MaxArgs || end < i {
+			return Token{}, false
+		}
+
+		token.Args, token.Arity, i = args, arity, end
+	}
+
+	if i >= len(input) || input[i] != ')' {
+		return Token{}, false
+	}
+
+	token.End = i + 1
+
+	return token, true
+}
+
+// lexOperand lexes an operand of 1 to digits digits starting at input[start], with
+// a leading minus sign if signed, returning its value and the index just after it.
+func lexOperand(input string, start, digits int, signed bool) (value, end int, ok bool) {
+	end = start
+	negative := signed && end < len(input) && input[end] == '-'
+	if negative {
+		end++
+	}
+
+	first := end
+	for end < len(input) && end-first < digits && isDigit(input[end]) {
+		value = value*10 + int(input[end]-'0')
+		end++
+	}
+
+	if negative {
+		value = -value
+	}
+
+	return value, end, end > first
+}
+
+// isDigit reports whether b is an ASCII digit.
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -0,0 +1,205 @@
+package day03
+
+import (
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/FollowTheProcess/test"
+)
+
+// allRegex is the regular expression day 3 used to be solved with, kept as a reference
+// implementation to check the lexer against.
+var allRegex = regexp.MustCompile(`mul\((\d{1,3}),(\d{1,3})\)|do\(\)|don't\(\)`)
+
+// lexRegex lexes the input with allRegex.
+func lexRegex(input string) []Token {
+	var tokens []Token
+	for _, match := range allRegex.FindAllStringSubmatchIndex(input, -1) {
+		token := Token{Start: match[0], End: match[1]}
+		switch input[match[0]:match[1]] {
+		case "do()":
+			token.Op = OpDo
+		case "don't()":
+			token.Op = OpDont
+		default:
+			token.Op = OpMul
+			token.Arity = 2
+			token.Args[0], _ = strconv.Atoi(input[match[2]:match[3]])
+			token.Args[1], _ = strconv.Atoi(input[match[4]:match[5]])
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens
+}
+
+// corrupted returns n bytes of randomly corrupted memory, made mostly of fragments
+// of instructions so plenty of them are nearly but not quite intact.
+func corrupted(n int, seed uint64) string {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	fragments := []string{
+		"mul", "(", ")", ",", "do", "don't", "n't", "'", "1", "23", "456", "7890",
+		"m", "u", "l", "[", "]", " ", "x", "%", "mul(", "do()", "don't()",
+	}
+
+	var b strings.Builder
+	for b.Len() < n {
+		b.WriteString(fragments[rng.IntN(len(fragments))])
+	}
+
+	return b.String()
+}
+
+func TestLex(t *testing.T) {
+	tests := []struct {
+		name  string  // Name of the test case
+		input string  // The corrupted memory
+		want  []Token // Expected tokens
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:  "nothing intact",
+			input: "mul[3,7]!@^do_not_mul(32,64]then(",
+			want:  nil,
+		},
+		{
+			name:  "mul",
+			input: "mul(2,4)",
+			want:  []Token{{Op: OpMul, Args: [MaxArgs]int{2, 4}, Arity: 2, Start: 0, End: 8}},
+		},
+		{
+			name:  "do and don't",
+			input: "xdo()_don't()",
+			want: []Token{
+				{Op: OpDo, Start: 1, End: 5},
+				{Op: OpDont, Start: 6, End: 13},
+			},
+		},
+		{
+			name:  "too many digits",
+			input: "mul(1234,5)mul(5,1234)",
+			want:  nil,
+		},
+		{
+			name:  "no operand",
+			input: "mul(,5)mul(5,)mul()",
+			want:  nil,
+		},
+		{
+			name:  "wrong closing bracket",
+			input: "mul(5,5]",
+			want:  nil,
+		},
+		{
+			name:  "truncated",
+			input: "mul(12,34",
+			want:  nil,
+		},
+		{
+			name:  "restarts inside a broken mul",
+			input: "mul(mul(1,2)",
+			want:  []Token{{Op: OpMul, Args: [MaxArgs]int{1, 2}, Arity: 2, Start: 4, End: 12}},
+		},
+		{
+			name:  "don't not mistaken for do",
+			input: "don't()do()",
+			want: []Token{
+				{Op: OpDont, Start: 0, End: 7},
+				{Op: OpDo, Start: 7, End: 11},
+			},
+		},
+		{
+			name:  "example",
+			input: testInputWithDosAndDonts,
+			want: []Token{
+				{Op: OpMul, Args: [MaxArgs]int{2, 4}, Arity: 2, Start: 1, End: 9},
+				{Op: OpDont, Start: 20, End: 27},
+				{Op: OpMul, Args: [MaxArgs]int{5, 5}, Arity: 2, Start: 28, End: 36},
+				{Op: OpMul, Args: [MaxArgs]int{11, 8}, Arity: 2, Start: 48, End: 57},
+				{Op: OpDo, Start: 59, End: 63},
+				{Op: OpMul, Args: [MaxArgs]int{8, 5}, Arity: 2, Start: 64, End: 72},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(puzzle.lex(tt.input))
+			test.EqualFunc(t, got, tt.want, slices.Equal)
+		})
+	}
+}
+
+func TestLexStopsEarly(t *testing.T) {
+	var got []Token
+	for token := range puzzle.lex(testInput) {
+		got = append(got, token)
+		if len(got) == 2 {
+			break
+		}
+	}
+
+	test.Equal(t, len(got), 2)
+}
+
+func TestLexMatchesRegex(t *testing.T) {
+	inputs := []string{testInput, testInputWithDosAndDonts, input}
+	for seed := range uint64(100) {
+		inputs = append(inputs, corrupted(1000, seed))
+	}
+
+	for _, input := range inputs {
+		got := slices.Collect(puzzle.lex(input))
+		want := lexRegex(input)
+		test.EqualFunc(t, got, want, slices.Equal)
+	}
+}
+
+func FuzzLex(f *testing.F) {
+	f.Add(testInput)
+	f.Add(testInputWithDosAndDonts)
+	f.Add("mul(1234,5)mul(mul(1,2)don't()do()")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		got := slices.Collect(puzzle.lex(input))
+		want := lexRegex(input)
+		test.EqualFunc(t, got, want, slices.Equal)
+	})
+}
+
+func BenchmarkLex(b *testing.B) {
+	inputs := []struct {
+		name  string // Name of the input
+		input string // The corrupted memory
+	}{
+		{name: "puzzle", input: input},
+		{name: "corrupted", input: corrupted(1<<20, 1)},
+	}
+
+	for _, in := range inputs {
+		b.Run(in.name+"/lexer", func(b *testing.B) {
+			b.SetBytes(int64(len(in.input)))
+			for range b.N {
+				for token := range puzzle.lex(in.input) {
+					_ = token
+				}
+			}
+		})
+
+		b.Run(in.name+"/regex", func(b *testing.B) {
+			b.SetBytes(int64(len(in.input)))
+			for range b.N {
+				lexRegex(in.input)
+			}
+		})
+	}
+}
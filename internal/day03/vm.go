@@ -0,0 +1,249 @@
+package day03
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// MaxArgs is the most operands an instruction may take.
+const MaxArgs = 4
+
+// Opcode identifies a registered instruction, the interpreter assigns them in the
+// order instructions are registered, starting from 0.
+type Opcode int
+
+// The opcodes of the puzzle's instructions in any interpreter built from [Puzzle].
+const (
+	OpMul  Opcode = iota // mul(X,Y)
+	OpDo                 // do()
+	OpDont               // don't()
+)
+
+// Machine is the state the instructions in the corrupted memory act on.
+type Machine struct {
+	blocks  []bool // Whether each open conditional block's enclosing code was enabled
+	Acc     int    // The accumulator, where results are added up
+	Enabled bool   // Whether instructions that respect it, like mul, take effect
+}
+
+// NewMachine returns a Machine in its initial state, enabled with nothing accumulated.
+func NewMachine() *Machine {
+	return &Machine{Enabled: true}
+}
+
+// Begin opens a conditional block, the instructions in it are only enabled if cond
+// is true and the code around the block is enabled.
+func (m *Machine) Begin(cond bool) {
+	m.blocks = append(m.blocks, m.Enabled)
+	m.Enabled = m.Enabled && cond
+}
+
+// End closes the innermost conditional block opened with Begin, restoring whether
+// instructions are enabled to what it was before the block.
+func (m *Machine) End() error {
+	if len(m.blocks) == 0 {
+		return errors.New("no open block to end")
+	}
+
+	last := len(m.blocks) - 1
+	m.Enabled = m.blocks[last]
+	m.blocks = m.blocks[:last]
+
+	return nil
+}
+
+// Instruction is an instruction that can appear in the corrupted memory.
+//
+// In the memory it's written as its Name followed by its arguments in brackets,
+// e.g. mul(2,4) or do(), where Args says what arguments look like. Anything else,
+// including spaces, means it's corrupted and is skipped over.
+//
+// If Args is nil or [Operands], Exec is always given exactly that many operands.
+// Any other Grammar decides the count for itself, so Exec must check len(args).
+type Instruction struct {
+	Args Grammar                            // Lexes its arguments, nil if it takes none
+	Exec func(m *Machine, args []int) error // The effect of the instruction on the machine
+	Name string                             // The name of the instruction, e.g. "mul"
+}
+
+// validate reports whether the instruction can be registered.
+func (i Instruction) validate() error {
+	var errs []error
+	if i.Name == "" {
+		errs = append(errs, errors.New("name must not be empty"))
+	}
+
+	if strings.ContainsAny(i.Name, "(,)") {
+		errs = append(errs, fmt.Errorf("name %q must not contain brackets or commas", i.Name))
+	}
+
+	if operands, ok := i.Args.(Operands); ok {
+		if operands.Count < 0 || operands.Count > MaxArgs {
+			errs = append(errs, fmt.Errorf("must take between 0 and %d operands, got %d", MaxArgs, operands.Count))
+		}
+
+		if operands.MaxDigits < 0 || operands.MaxDigits > maxOperandDigits {
+			errs = append(
+				errs,
+				fmt.Errorf("operands must have between 0 and %d digits, got %d", maxOperandDigits, operands.MaxDigits),
+			)
+		}
+	}
+
+	if i.Exec == nil {
+		errs = append(errs, errors.New("must have an Exec effect"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Interpreter runs the instructions found in corrupted memory, the instructions it
+// knows about are registered with it rather than built in.
+type Interpreter struct {
+	byName       map[string]Opcode // The opcode of every registered instruction by name
+	byFirstByte  [256][]Opcode     // The opcodes of the instructions starting with each byte
+	instructions []Instruction     // Every registered instruction, indexed by opcode
+	arities      []int             // How many operands each instruction takes, indexed by opcode
+}
+
+// anyArity is the arity of an instruction with a custom Grammar, which decides for
+// itself how many operands there are.
+const anyArity = -1
+
+// arity returns how many operands the instruction takes, or anyArity if its
+// Grammar decides.
+func (i Instruction) arity() int {
+	switch args := i.Args.(type) {
+	case nil:
+		return 0
+	case Operands:
+		return args.Count
+	default:
+		return anyArity
+	}
+}
+
+// NewInterpreter returns an Interpreter with the given instructions registered.
+func NewInterpreter(instructions ...Instruction) (*Interpreter, error) {
+	in := &Interpreter{byName: make(map[string]Opcode, len(instructions))}
+	for _, instruction := range instructions {
+		if _, err := in.Register(instruction); err != nil {
+			return nil, err
+		}
+	}
+
+	return in, nil
+}
+
+// Puzzle returns an Interpreter for the puzzle's instructions: mul(X,Y) adds X*Y
+// to the accumulator while enabled, do() enables it and don't() disables it.
+//
+// They're registered first, in that order, so their opcodes are OpMul, OpDo and
+// OpDont.
+func Puzzle() *Interpreter {
+	in, err := NewInterpreter(
+		Instruction{Name: "mul", Args: Operands{Count: 2}, Exec: mul},
+		Instruction{Name: "do", Exec: do},
+		Instruction{Name: "don't", Exec: dont},
+	)
+	if err != nil {
+		panic(fmt.Sprintf("bad puzzle instruction: %v", err))
+	}
+
+	return in
+}
+
+// Register teaches the interpreter a new instruction, returning the opcode its
+// tokens will have. Its name must not already be registered.
+func (in *Interpreter) Register(instruction Instruction) (Opcode, error) {
+	if err := instruction.validate(); err != nil {
+		return 0, fmt.Errorf("bad instruction %q: %w", instruction.Name, err)
+	}
+
+	if _, exists := in.byName[instruction.Name]; exists {
+		return 0, fmt.Errorf("instruction %q is already registered", instruction.Name)
+	}
+
+	op := Opcode(len(in.instructions))
+	in.byName[instruction.Name] = op
+	first := instruction.Name[0]
+	in.byFirstByte[first] = append(in.byFirstByte[first], op)
+	in.instructions = append(in.instructions, instruction)
+	in.arities = append(in.arities, instruction.arity())
+
+	return op, nil
+}
+
+// Instructions returns the names of the registered instructions, in the order they
+// were registered, so indexed by opcode.
+func (in *Interpreter) Instructions() []string {
+	names := make([]string, 0, len(in.instructions))
+	for _, instruction := range in.instructions {
+		names = append(names, instruction.Name)
+	}
+
+	return names
+}
+
+// Run lexes the corrupted memory and executes every intact instruction in it, in a
+// single pass, on a new Machine which it returns.
+func (in *Interpreter) Run(input string) (*Machine, error) {
+	m := NewMachine()
+	if err := in.Exec(m, in.lex(input)); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Exec executes the tokens on the machine in order, stopping at the first one that
+// fails, or that has a different number of operands to the instruction it names.
+func (in *Interpreter) Exec(m *Machine, tokens iter.Seq[Token]) error {
+	// Copying each token's operands into the one array means only it escapes to the
+	// heap, rather than every token
+	var args [MaxArgs]int
+	for token := range tokens {
+		if token.Op < 0 || int(token.Op) >= len(in.instructions) {
+			return fmt.Errorf("unknown opcode %d at byte %d", token.Op, token.Start)
+		}
+
+		if token.Arity < 0 || token.Arity > MaxArgs {
+			return fmt.Errorf("bad operand count %d at byte %d", token.Arity, token.Start)
+		}
+
+		instruction := in.instructions[token.Op]
+		if want := in.arities[token.Op]; want != anyArity && token.Arity != want {
+			return fmt.Errorf("%s at byte %d: takes %d operands, got %d", instruction.Name, token.Start, want, token.Arity)
+		}
+
+		args = token.Args
+		if err := instruction.Exec(m, args[:token.Arity]); err != nil {
+			return fmt.Errorf("%s at byte %d: %w", instruction.Name, token.Start, err)
+		}
+	}
+
+	return nil
+}
+
+// mul adds the product of its two operands to the accumulator, if enabled.
+func mul(m *Machine, args []int) error {
+	if m.Enabled {
+		m.Acc += args[0] * args[1]
+	}
+
+	return nil
+}
+
+// do enables the instructions after it.
+func do(m *Machine, _ []int) error {
+	m.Enabled = true
+	return nil
+}
+
+// dont disables the instructions after it.
+func dont(m *Machine, _ []int) error {
+	m.Enabled = false
+	return nil
+}
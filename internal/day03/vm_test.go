@@ -0,0 +1,431 @@
+package day03
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/FollowTheProcess/test"
+)
+
+// variants returns the puzzle's instructions plus some made up ones a different
+// corrupted program might use.
+func variants(t *testing.T) *Interpreter {
+	t.Helper()
+
+	in := Puzzle()
+	extra := []Instruction{
+		{
+			Name: "add",
+			Args: Operands{Count: 2},
+			Exec: func(m *Machine, args []int) error {
+				if m.Enabled {
+					m.Acc += args[0] + args[1]
+				}
+				return nil
+			},
+		},
+		{
+			Name: "sub",
+			Args: Operands{Count: 2},
+			Exec: func(m *Machine, args []int) error {
+				if m.Enabled {
+					m.Acc -= args[0] - args[1]
+				}
+				return nil
+			},
+		},
+		{
+			Name: "reset",
+			Exec: func(m *Machine, _ []int) error {
+				if m.Enabled {
+					m.Acc = 0
+				}
+				return nil
+			},
+		},
+		{
+			Name: "if",
+			Args: Operands{Count: 1},
+			Exec: func(m *Machine, args []int) error {
+				m.Begin(args[0] != 0)
+				return nil
+			},
+		},
+		{
+			Name: "end",
+			Exec: func(m *Machine, _ []int) error {
+				return m.End()
+			},
+		},
+	}
+
+	for _, instruction := range extra {
+		_, err := in.Register(instruction)
+		test.Ok(t, err)
+	}
+
+	return in
+}
+
+func TestInterpreterRun(t *testing.T) {
+	tests := []struct {
+		name  string // Name of the test case
+		input string // The corrupted memory
+		want  int    // Expected accumulator once it's run
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  0,
+		},
+		{
+			name:  "arithmetic",
+			input: "mul(2,3)add(1,2)sub(4,1)",
+			want:  6,
+		},
+		{
+			name:  "reset",
+			input: "mul(2,3)reset()mul(1,1)",
+			want:  1,
+		},
+		{
+			name:  "reset while disabled",
+			input: "mul(5,5)don't()reset()",
+			want:  25,
+		},
+		{
+			name:  "disabled add",
+			input: "don't()add(5,5)do()add(1,1)",
+			want:  2,
+		},
+		{
+			name:  "false block",
+			input: "if(0)mul(2,2)end()mul(3,3)",
+			want:  9,
+		},
+		{
+			name:  "nested blocks",
+			input: "if(1)mul(2,2)if(0)mul(3,3)end()mul(1,1)end()",
+			want:  5,
+		},
+		{
+			name:  "true block while disabled",
+			input: "don't()if(1)mul(2,2)end()do()mul(1,1)",
+			want:  1,
+		},
+		{
+			name:  "corrupted",
+			input: "xadd(1,2]sub( 1,1)if(1234)mul(4,5)",
+			want:  20,
+		},
+		{
+			name:  "example",
+			input: testInputWithDosAndDonts,
+			want:  48,
+		},
+	}
+
+	in := variants(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := in.Run(tt.input)
+			test.Ok(t, err)
+			test.Equal(t, m.Acc, tt.want)
+		})
+	}
+}
+
+func TestInterpreterUnregistered(t *testing.T) {
+	// add isn't one of the puzzle's instructions so it's just more corruption
+	m, err := Puzzle().Run("add(1,2)mul(3,4)")
+	test.Ok(t, err)
+	test.Equal(t, m.Acc, 12)
+}
+
+func TestInterpreterRunError(t *testing.T) {
+	_, err := variants(t).Run("mul(1,2)xend()")
+	test.Err(t, err)
+	test.Equal(t, err.Error(), "end at byte 9: no open block to end")
+}
+
+func TestInterpreterExecUnknown(t *testing.T) {
+	// Opcode 3 is add in variants, but the puzzle only knows 3 instructions
+	tokens := slices.Values([]Token{{Op: 3, Args: [MaxArgs]int{1, 2}, Arity: 2, Start: 3, End: 11}})
+	err := Puzzle().Exec(NewMachine(), tokens)
+	test.Err(t, err)
+	test.Equal(t, err.Error(), "unknown opcode 3 at byte 3")
+}
+
+func TestInterpreterExecArity(t *testing.T) {
+	tests := []struct {
+		name  string // Name of the test case
+		want  string // Expected error
+		token Token  // The token to execute
+	}{
+		{
+			name:  "too few",
+			token: Token{Op: OpMul, Args: [MaxArgs]int{2}, Arity: 1, Start: 5},
+			want:  "mul at byte 5: takes 2 operands, got 1",
+		},
+		{
+			name:  "too many",
+			token: Token{Op: OpDo, Args: [MaxArgs]int{1}, Arity: 1, Start: 2},
+			want:  "do at byte 2: takes 0 operands, got 1",
+		},
+		{
+			name:  "out of range",
+			token: Token{Op: OpMul, Arity: MaxArgs + 1},
+			want:  "bad operand count 5 at byte 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Puzzle().Exec(NewMachine(), slices.Values([]Token{tt.token}))
+			test.Err(t, err)
+			test.Equal(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestInterpreterExecAnyArity(t *testing.T) {
+	// A custom grammar decides how many operands there are, so any count is fine
+	in, err := NewInterpreter(Instruction{
+		Name: "count",
+		Args: GrammarFunc(pairs),
+		Exec: func(m *Machine, args []int) error {
+			m.Acc += len(args)
+			return nil
+		},
+	})
+	test.Ok(t, err)
+
+	m := NewMachine()
+	tokens := []Token{{Arity: 2}, {Arity: 4}, {Arity: 0}}
+	test.Ok(t, in.Exec(m, slices.Values(tokens)))
+	test.Equal(t, m.Acc, 6)
+}
+
+func TestPuzzleOpcodes(t *testing.T) {
+	names := Puzzle().Instructions()
+	test.Equal(t, names[OpMul], "mul")
+	test.Equal(t, names[OpDo], "do")
+	test.Equal(t, names[OpDont], "don't")
+}
+
+func TestRegister(t *testing.T) {
+	nop := func(*Machine, []int) error { return nil }
+
+	tests := []struct {
+		name        string      // Name of the test case
+		instruction Instruction // The instruction to register
+		wantErr     bool        // Whether registering it should fail
+	}{
+		{
+			name:        "valid",
+			instruction: Instruction{Name: "nop", Exec: nop},
+			wantErr:     false,
+		},
+		{
+			name:        "max args",
+			instruction: Instruction{Name: "sum", Args: Operands{Count: MaxArgs}, Exec: nop},
+			wantErr:     false,
+		},
+		{
+			name:        "empty name",
+			instruction: Instruction{Exec: nop},
+			wantErr:     true,
+		},
+		{
+			name:        "bracket in name",
+			instruction: Instruction{Name: "mul(", Exec: nop},
+			wantErr:     true,
+		},
+		{
+			name:        "comma in name",
+			instruction: Instruction{Name: "a,b", Exec: nop},
+			wantErr:     true,
+		},
+		{
+			name:        "custom grammar",
+			instruction: Instruction{Name: "any", Args: GrammarFunc(pairs), Exec: nop},
+			wantErr:     false,
+		},
+		{
+			name:        "too many args",
+			instruction: Instruction{Name: "big", Args: Operands{Count: MaxArgs + 1}, Exec: nop},
+			wantErr:     true,
+		},
+		{
+			name:        "negative args",
+			instruction: Instruction{Name: "neg", Args: Operands{Count: -1}, Exec: nop},
+			wantErr:     true,
+		},
+		{
+			name:        "too many digits",
+			instruction: Instruction{Name: "huge", Args: Operands{Count: 1, MaxDigits: 19}, Exec: nop},
+			wantErr:     true,
+		},
+		{
+			name:        "no effect",
+			instruction: Instruction{Name: "nothing"},
+			wantErr:     true,
+		},
+		{
+			name:        "already registered",
+			instruction: Instruction{Name: "mul", Args: Operands{Count: 2}, Exec: nop},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := Puzzle()
+			op, err := in.Register(tt.instruction)
+			test.WantErr(t, err, tt.wantErr)
+			if !tt.wantErr {
+				test.Equal(t, op, OpDont+1) // Should follow on from the puzzle's opcodes
+			}
+
+			registered := slices.Contains(in.Instructions(), tt.instruction.Name)
+			test.Equal(t, registered, !tt.wantErr || tt.name == "already registered")
+		})
+	}
+}
+
+func TestRegisterMaxArgs(t *testing.T) {
+	in, err := NewInterpreter(Instruction{
+		Name: "sum",
+		Args: Operands{Count: MaxArgs},
+		Exec: func(m *Machine, args []int) error {
+			for _, arg := range args {
+				m.Acc += arg
+			}
+			return nil
+		},
+	})
+	test.Ok(t, err)
+
+	m, err := in.Run("sum(1,2,3)sum(1,2,3,4)sum(1,2,3,4,5)")
+	test.Ok(t, err)
+	test.Equal(t, m.Acc, 10)
+}
+
+// pairs is an argument grammar unlike the puzzle's, 1 or 2 pairs of digits
+// written XxY and separated by semicolons, e.g. 3x4;5x6.
+func pairs(input string, start int) (args [MaxArgs]int, n, end int, ok bool) {
+	end = start
+	for n < MaxArgs {
+		if n > 0 {
+			if end >= len(input) || input[end] != ';' {
+				break
+			}
+			end++
+		}
+
+		if end+3 > len(input) || !isDigit(input[end]) || input[end+1] != 'x' || !isDigit(input[end+2]) {
+			return args, 0, 0, false
+		}
+
+		args[n] = int(input[end] - '0')
+		args[n+1] = int(input[end+2] - '0')
+		n += 2
+		end += 3
+	}
+
+	return args, n, end, true
+}
+
+func TestRegisterGrammar(t *testing.T) {
+	// sum adds up its operands, whatever the grammar allows
+	sum := func(m *Machine, args []int) error {
+		for _, arg := range args {
+			m.Acc += arg
+		}
+		return nil
+	}
+
+	tests := []struct {
+		args  Grammar // The argument grammar of the sum instruction
+		name  string  // Name of the test case
+		input string  // The corrupted memory
+		want  int     // Expected accumulator once it's run
+	}{
+		{
+			name:  "signed",
+			args:  Operands{Count: 2, Signed: true},
+			input: "sum(-5,3)sum(2,-1)sum(--1,1)sum(-,1)sum(1-,1)",
+			want:  -1,
+		},
+		{
+			name:  "unsigned rejects signs",
+			args:  Operands{Count: 2},
+			input: "sum(-5,3)sum(2,1)",
+			want:  3,
+		},
+		{
+			name:  "wider",
+			args:  Operands{Count: 1, MaxDigits: 6},
+			input: "sum(123456)sum(1234567)sum(1)",
+			want:  123457,
+		},
+		{
+			name:  "widest",
+			args:  Operands{Count: 1, MaxDigits: maxOperandDigits, Signed: true},
+			input: "sum(-999999999999999999)",
+			want:  -999999999999999999,
+		},
+		{
+			name:  "custom",
+			args:  GrammarFunc(pairs),
+			input: "sum(3x4)sum(1x1;2x2)sum(1,1)sum(1x)sum(1x1;)",
+			want:  13,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in, err := NewInterpreter(Instruction{Name: "sum", Args: tt.args, Exec: sum})
+			test.Ok(t, err)
+
+			m, err := in.Run(tt.input)
+			test.Ok(t, err)
+			test.Equal(t, m.Acc, tt.want)
+		})
+	}
+}
+
+func TestInstructions(t *testing.T) {
+	got := variants(t).Instructions()
+	want := []string{"mul", "do", "don't", "add", "sub", "reset", "if", "end"}
+	test.EqualFunc(t, got, want, slices.Equal)
+}
+
+func TestMachineEnd(t *testing.T) {
+	m := NewMachine()
+	test.Err(t, m.End()) // No block open
+
+	m.Begin(false)
+	test.False(t, m.Enabled)
+
+	m.Begin(true)
+	test.False(t, m.Enabled) // Still inside a false block
+
+	test.Ok(t, m.End())
+	test.False(t, m.Enabled)
+
+	test.Ok(t, m.End())
+	test.True(t, m.Enabled)
+}
+
+func BenchmarkInterpreterRun(b *testing.B) {
+	memory := corrupted(1<<20, 1)
+	in := Puzzle()
+
+	b.SetBytes(int64(len(memory)))
+	for range b.N {
+		if _, err := in.Run(memory); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Package days is the registry of every solved day, it is how the aoc runner
+// finds a day's solution without needing to know where it lives.
+//
+// The list of days in registry.go is generated by "aoc new", which keeps it in
+// sync with the day packages on disk.
+package days
+
+import (
+	"fmt"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+)
+
+// Get returns the registered day with the given number, or an error if that
+// day has not been solved yet.
+func Get(number int) (aoc.Day, error) {
+	for _, day := range All() {
+		if day.Number == number {
+			return day, nil
+		}
+	}
+
+	return aoc.Day{}, fmt.Errorf("day %d is not registered", number)
+}
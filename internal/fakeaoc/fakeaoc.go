@@ -0,0 +1,256 @@
+// Package fakeaoc is an in-process stand-in for the Advent of Code website, so that
+// the client can be tested end to end without a network connection.
+package fakeaoc
+
+import (
+	"embed"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responses are answer responses recorded from the real website, with the day
+// and wait time replaced by {{DAY}} and {{WAIT}}.
+//
+//go:embed responses/*.html
+var responses embed.FS
+
+// Responses the real website sends, copied verbatim.
+const (
+	notLoggedIn = "Puzzle inputs differ by user.  Please log in to get your puzzle input.\n"
+	notUnlocked = "Please don't repeatedly request this endpoint before it unlocks! " +
+		"The calendar countdown is synchronized with the server time; " +
+		"the link will be enabled on the calendar the instant this puzzle becomes available.\n"
+)
+
+// Request is a request the server has received.
+type Request struct {
+	Method    string // The HTTP method
+	Path      string // The URL path
+	UserAgent string // The User-Agent header
+}
+
+// level identifies one part of one day.
+type level struct {
+	day  int
+	part int
+}
+
+// Server is a fake Advent of Code website.
+type Server struct {
+	*httptest.Server
+
+	waitUntil time.Time        // No answers are accepted before this time
+	inputs    map[int]string   // Puzzle inputs by day
+	answers   map[level]string // Correct answers
+	solved    map[level]bool   // Levels that have been answered correctly
+	session   string           // The only session cookie the server accepts
+	requests  []Request        // Every request received, in order
+	penalty   time.Duration    // How long a wrong answer locks out further answers
+	year      int              // The event year served
+	mu        sync.Mutex       // Protects everything above
+}
+
+// New starts a new fake website serving the given year, accepting only the given
+// session. Callers should Close it when done.
+func New(year int, session string) *Server {
+	s := &Server{
+		inputs:  make(map[int]string),
+		answers: make(map[level]string),
+		solved:  make(map[level]bool),
+		session: session,
+		penalty: time.Minute,
+		year:    year,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{year}/day/{day}/input", s.input)
+	mux.HandleFunc("POST /{year}/day/{day}/answer", s.answer)
+
+	s.Server = httptest.NewServer(s.record(mux))
+
+	return s
+}
+
+// SetInput sets the puzzle input served for a day.
+func (s *Server) SetInput(day int, input string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inputs[day] = input
+}
+
+// SetAnswer sets the correct answer for one part of a day.
+func (s *Server) SetAnswer(day, part int, answer string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.answers[level{day: day, part: part}] = answer
+}
+
+// SetPenalty sets how long a wrong answer locks out further answers, the real
+// website starts at one minute.
+func (s *Server) SetPenalty(penalty time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.penalty = penalty
+}
+
+// Requests returns every request the server has received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// record wraps next, recording every request.
+func (s *Server) record(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, UserAgent: r.UserAgent()})
+		s.mu.Unlock()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// input serves a day's puzzle input.
+func (s *Server) input(w http.ResponseWriter, r *http.Request) {
+	if !s.loggedIn(r) {
+		http.Error(w, notLoggedIn, http.StatusBadRequest)
+		return
+	}
+
+	day, ok := s.day(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	input, ok := s.inputs[day]
+	s.mu.Unlock()
+
+	if !ok {
+		http.Error(w, notUnlocked, http.StatusNotFound)
+		return
+	}
+
+	fmt.Fprint(w, input)
+}
+
+// answer judges a submitted answer, replying with the matching recorded response.
+func (s *Server) answer(w http.ResponseWriter, r *http.Request) {
+	if !s.loggedIn(r) {
+		http.Error(w, notLoggedIn, http.StatusBadRequest)
+		return
+	}
+
+	day, ok := s.day(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	part, err := strconv.Atoi(r.PostFormValue("level"))
+	if err != nil || (part != 1 && part != 2) {
+		http.Error(w, "bad level", http.StatusBadRequest)
+		return
+	}
+
+	submitted := strings.TrimSpace(r.PostFormValue("answer"))
+	key := level{day: day, part: part}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	want, ok := s.answers[key]
+	if !ok {
+		http.Error(w, notUnlocked, http.StatusNotFound)
+		return
+	}
+
+	now := time.Now()
+	var response string
+
+	switch {
+	case s.solved[key]:
+		response = "already_solved"
+	case now.Before(s.waitUntil):
+		response = "too_recent"
+	case submitted == want:
+		s.solved[key] = true
+		response = "correct"
+	default:
+		s.waitUntil = now.Add(s.penalty)
+		response = hint(submitted, want)
+	}
+
+	page, err := responses.ReadFile("responses/" + response + ".html")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	replacer := strings.NewReplacer(
+		"{{DAY}}", strconv.Itoa(day),
+		"{{WAIT}}", formatWait(s.waitUntil.Sub(now)),
+	)
+
+	w.Header().Set("Content-Type", "text/html")
+	fmt.Fprint(w, replacer.Replace(string(page)))
+}
+
+// hint returns the name of the wrong answer response for submitted, which like the
+// real website says whether a numeric answer is too high or too low.
+func hint(submitted, want string) string {
+	got, err := strconv.Atoi(submitted)
+	if err != nil {
+		return "incorrect"
+	}
+
+	correct, err := strconv.Atoi(want)
+	if err != nil {
+		return "incorrect"
+	}
+
+	if got > correct {
+		return "too_high"
+	}
+
+	return "too_low"
+}
+
+// formatWait formats a remaining wait the way the website does e.g. "43s" or "1m 3s".
+func formatWait(wait time.Duration) string {
+	seconds := int(wait.Round(time.Second).Seconds())
+	if seconds < 60 {
+		return fmt.Sprintf("%ds", seconds)
+	}
+
+	return fmt.Sprintf("%dm %ds", seconds/60, seconds%60)
+}
+
+// loggedIn reports whether the request carries the accepted session cookie.
+func (s *Server) loggedIn(r *http.Request) bool {
+	cookie, err := r.Cookie("session")
+	return err == nil && cookie.Value == s.session
+}
+
+// day returns the day from the request path, reporting whether it is a valid
+// day of the served year.
+func (s *Server) day(r *http.Request) (int, bool) {
+	year, err := strconv.Atoi(r.PathValue("year"))
+	if err != nil || year != s.year {
+		return 0, false
+	}
+
+	day, err := strconv.Atoi(r.PathValue("day"))
+	if err != nil || day < 1 || day > 25 {
+		return 0, false
+	}
+
+	return day, true
+}
@@ -0,0 +1,52 @@
+// Package parse holds the small allocation free helpers the days share for parsing
+// lines of numbers.
+package parse
+
+import "math"
+
+// IsBlank reports whether b separates the numbers on a line: a space, a tab, or the
+// carriage return of a \r\n line ending.
+func IsBlank(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r'
+}
+
+// Int parses an optionally signed base 10 integer without allocating, ok is false
+// if raw isn't a valid integer or doesn't fit in an int.
+func Int[T ~string | ~[]byte](raw T) (n int, ok bool) {
+	negative := false
+	if len(raw) > 0 && (raw[0] == '-' || raw[0] == '+') {
+		negative = raw[0] == '-'
+		raw = raw[1:]
+	}
+
+	if len(raw) == 0 {
+		return 0, false
+	}
+
+	// Work with the magnitude, which for math.MinInt is one more than math.MaxInt
+	limit := uint64(math.MaxInt)
+	if negative {
+		limit++
+	}
+
+	var magnitude uint64
+	for i := range len(raw) {
+		b := raw[i]
+		if b < '0' || b > '9' {
+			return 0, false
+		}
+
+		digit := uint64(b - '0')
+		if magnitude > (limit-digit)/10 {
+			return 0, false
+		}
+
+		magnitude = magnitude*10 + digit
+	}
+
+	if negative {
+		return int(-magnitude), true
+	}
+
+	return int(magnitude), true
+}
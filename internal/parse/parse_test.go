@@ -0,0 +1,63 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/FollowTheProcess/test"
+)
+
+func TestInt(t *testing.T) {
+	tests := []struct {
+		raw  string // The raw integer
+		want int    // The expected value
+		ok   bool   // Whether it should parse
+	}{
+		{raw: "0", want: 0, ok: true},
+		{raw: "12345", want: 12345, ok: true},
+		{raw: "-7", want: -7, ok: true},
+		{raw: "+7", want: 7, ok: true},
+		{raw: "-0", want: 0, ok: true},
+		{raw: "007", want: 7, ok: true},
+		{raw: "9223372036854775807", want: 9223372036854775807, ok: true},
+		{raw: "9223372036854775808", ok: false},
+		{raw: "-9223372036854775808", want: -9223372036854775808, ok: true},
+		{raw: "-9223372036854775809", ok: false},
+		{raw: "99999999999999999999", ok: false},
+		{raw: "", ok: false},
+		{raw: "-", ok: false},
+		{raw: "+-1", ok: false},
+		{raw: "1_000", ok: false},
+		{raw: "0x10", ok: false},
+		{raw: " 1", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, ok := Int(tt.raw)
+			test.Equal(t, ok, tt.ok)
+			test.Equal(t, got, tt.want)
+
+			got, ok = Int([]byte(tt.raw))
+			test.Equal(t, ok, tt.ok)
+			test.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestIntAllocations(t *testing.T) {
+	raw := []byte("-123456789")
+	allocs := testing.AllocsPerRun(100, func() {
+		Int(raw)
+	})
+	test.Equal(t, allocs, 0.0)
+}
+
+func TestIsBlank(t *testing.T) {
+	for _, b := range []byte(" \t\r") {
+		test.True(t, IsBlank(b))
+	}
+
+	for _, b := range []byte("0-+\nx") {
+		test.False(t, IsBlank(b))
+	}
+}
@@ -0,0 +1,104 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+)
+
+// Format is an output format for the results of a run.
+type Format string
+
+const (
+	// FormatText is a human readable table, see [WriteTable].
+	FormatText Format = "text"
+
+	// FormatJSON is a single JSON array of [Record].
+	FormatJSON Format = "json"
+
+	// FormatNDJSON is newline delimited JSON, one [Record] per line.
+	FormatNDJSON Format = "ndjson"
+)
+
+// ParseFormat parses a Format from its name.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case FormatText, FormatJSON, FormatNDJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown format %q, expected one of %q, %q or %q", name, FormatText, FormatJSON, FormatNDJSON)
+	}
+}
+
+// Record is the machine readable result of solving one part of a day.
+//
+// A day that streams its input solves both parts as it parses, so all of its cost
+// is in ParseDuration and ParseAllocs and its solve figures are 0.
+type Record struct {
+	Answer        aoc.Answer `json:"answer"`          // The answer, empty if there was an error
+	Error         string     `json:"error,omitempty"` // The parse or solve error, if any
+	Year          int        `json:"year"`            // The event year
+	Day           int        `json:"day"`             // The day number
+	Part          int        `json:"part"`            // The part, 1 or 2
+	Duration      int64      `json:"duration"`        // Time to solve the part in nanoseconds, excluding parsing
+	ParseDuration int64      `json:"parse_duration"`  // Time to parse the day's input in nanoseconds, shared by both parts
+	Allocs        uint64     `json:"allocs"`          // Heap allocations made solving the part
+	ParseAllocs   uint64     `json:"parse_allocs"`    // Heap allocations made parsing the day's input
+}
+
+// Records flattens the results into one Record per part, in day then part order.
+//
+// If a day's input failed to parse, both of its parts are reported with the
+// parse error.
+func Records(results Results) []Record {
+	records := make([]Record, 0, 2*len(results.Days))
+	for _, result := range results.Days {
+		for i, part := range result.Parts {
+			record := Record{
+				Year:          aoc.Year,
+				Day:           result.Day,
+				Part:          i + 1,
+				Answer:        part.Answer,
+				Duration:      part.Duration.Nanoseconds(),
+				ParseDuration: result.Parse.Duration.Nanoseconds(),
+				Allocs:        part.Allocs,
+				ParseAllocs:   result.Parse.Allocs,
+			}
+
+			switch {
+			case result.Err != nil:
+				record.Error = result.Err.Error()
+			case part.Err != nil:
+				record.Error = part.Err.Error()
+			}
+
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
+// Write writes the results to w in the given format.
+func Write(w io.Writer, format Format, results Results) error {
+	switch format {
+	case FormatText:
+		return WriteTable(w, results)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(Records(results))
+	case FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, record := range Records(results) {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
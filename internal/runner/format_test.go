@@ -0,0 +1,97 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/test"
+)
+
+func TestParseFormat(t *testing.T) {
+	for _, name := range []string{"text", "json", "ndjson"} {
+		format, err := ParseFormat(name)
+		test.Ok(t, err)
+		test.Equal(t, string(format), name)
+	}
+
+	_, err := ParseFormat("yaml")
+	test.Err(t, err)
+}
+
+func TestRecords(t *testing.T) {
+	results := Run([]aoc.Day{fakeDay(1, "one"), fakeDay(2, "bad"), fakeDay(3, "unsolved")}, 1)
+
+	got := Records(results)
+
+	// Durations and allocations are non-deterministic so blank them out
+	for i := range got {
+		got[i].Duration, got[i].ParseDuration = 0, 0
+		got[i].Allocs, got[i].ParseAllocs = 0, 0
+	}
+
+	want := []Record{
+		{Year: 2024, Day: 1, Part: 1, Answer: "one-1"},
+		{Year: 2024, Day: 1, Part: 2, Answer: "one-2"},
+		{Year: 2024, Day: 2, Part: 1, Error: "bad input\nwith some detail"},
+		{Year: 2024, Day: 2, Part: 2, Error: "bad input\nwith some detail"},
+		{Year: 2024, Day: 3, Part: 1, Answer: "unsolved-1"},
+		{Year: 2024, Day: 3, Part: 2, Error: "not done yet"},
+	}
+
+	test.Diff(t, got, want)
+}
+
+func TestRecordsStream(t *testing.T) {
+	open := func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("streamed")), nil
+	}
+	day := aoc.Day{Number: 1, Open: open, New: func() aoc.Solution { return &streamer{} }}
+
+	records := Records(Run([]aoc.Day{day}, 1))
+	test.Equal(t, len(records), 2)
+
+	// Both parts are solved while parsing, so that's where the cost shows up
+	for _, record := range records {
+		test.Equal(t, record.Duration, 0)
+		test.True(t, record.ParseDuration > 0)
+		test.Equal(t, record.ParseDuration, records[0].ParseDuration) // Shared by both parts
+	}
+}
+
+func TestWrite(t *testing.T) {
+	results := Run([]aoc.Day{fakeDay(1, "one"), fakeDay(2, "two")}, 1)
+
+	t.Run("json", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		test.Ok(t, Write(buf, FormatJSON, results))
+
+		var records []Record
+		test.Ok(t, json.Unmarshal(buf.Bytes(), &records))
+		test.Equal(t, len(records), 4)
+		test.Equal(t, records[3].Answer, aoc.Answer("two-2"))
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		test.Ok(t, Write(buf, FormatNDJSON, results))
+
+		lines := 0
+		scanner := bufio.NewScanner(buf)
+		for scanner.Scan() {
+			var record Record
+			test.Ok(t, json.Unmarshal(scanner.Bytes(), &record))
+			lines++
+		}
+
+		test.Equal(t, lines, 4)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		test.Err(t, Write(&bytes.Buffer{}, Format("xml"), results))
+	})
+}
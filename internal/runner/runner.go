@@ -0,0 +1,232 @@
+// Package runner parses and solves registered days concurrently, measuring how long
+// each step takes and how much it allocates.
+package runner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+)
+
+// Measurement is the cost of a single step of solving a day.
+type Measurement struct {
+	Duration time.Duration // How long the step took
+	Allocs   uint64        // Number of heap allocations made during the step
+}
+
+// Part is the result of solving one part of a day's puzzle.
+type Part struct {
+	Err         error      // The error solving this part, if any
+	Answer      aoc.Answer // The answer to this part
+	Measurement            // The cost of solving this part
+}
+
+// Result is the outcome of parsing and solving a single day.
+type Result struct {
+	Err   error       // The error parsing the input, if any, in which case Parts is empty
+	Parts [2]Part     // The results of part 1 and part 2
+	Parse Measurement // The cost of parsing the input
+	Day   int         // The day number
+}
+
+// Results is the outcome of a whole run.
+type Results struct {
+	Days    []Result      // One result per day, in the order the days were given
+	Elapsed time.Duration // Wall clock time for the whole run
+}
+
+// Err returns a combined error of every parse or solve failure in the run, or nil
+// if every day succeeded. Parts that return [aoc.ErrUnsolved] aren't failures.
+func (r Results) Err() error {
+	var errs []error
+	for _, result := range r.Days {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("day %d: %w", result.Day, result.Err))
+			continue
+		}
+
+		for i, part := range result.Parts {
+			if part.Err != nil && !errors.Is(part.Err, aoc.ErrUnsolved) {
+				errs = append(errs, fmt.Errorf("day %d part %d: %w", result.Day, i+1, part.Err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run parses and solves every day, using at most workers goroutines at once.
+//
+// If workers is less than 1, [runtime.NumCPU] is used instead.
+//
+// Allocations are read from a process-wide counter so with more than one worker,
+// a day's count may include allocations made by days running alongside it. Use
+// a single worker when the allocation figures need to be exact.
+func Run(days []aoc.Day, workers int) Results {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	start := time.Now()
+
+	results := make([]Result, len(days))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(workers, len(days)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				// Each worker writes only to its own index so no locking needed
+				results[index] = solve(days[index])
+			}
+		}()
+	}
+
+	for index := range days {
+		jobs <- index
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return Results{Days: results, Elapsed: time.Since(start)}
+}
+
+// solve parses and solves a single day, measuring each step.
+func solve(day aoc.Day) Result {
+	result := Result{Day: day.Number}
+	solution := day.New()
+
+	if day.Open != nil {
+		return stream(day, solution)
+	}
+
+	result.Parse = measure(func() {
+		result.Err = solution.Parse(day.Input)
+	})
+
+	if result.Err != nil {
+		return result
+	}
+
+	for i, part := range [...]func() (aoc.Answer, error){solution.Part1, solution.Part2} {
+		result.Parts[i].Measurement = measure(func() {
+			result.Parts[i].Answer, result.Parts[i].Err = part()
+		})
+	}
+
+	return result
+}
+
+// stream solves a single day by streaming its input through the solution, which
+// must be an [aoc.Streamer]. Both parts are solved in the one pass so the whole
+// cost is counted as parsing.
+func stream(day aoc.Day, solution aoc.Solution) Result {
+	result := Result{Day: day.Number}
+
+	streamer, ok := solution.(aoc.Streamer)
+	if !ok {
+		result.Err = errors.New("solution can't stream its input")
+		return result
+	}
+
+	result.Parse = measure(func() {
+		r, err := day.Open()
+		if err != nil {
+			result.Err = err
+			return
+		}
+		defer r.Close()
+
+		var answers [2]aoc.Answer
+		answers, result.Err = streamer.Stream(r)
+		result.Parts[0].Answer, result.Parts[1].Answer = answers[0], answers[1]
+	})
+
+	return result
+}
+
+// measure calls fn, returning how long it took and how many allocations it made.
+func measure(fn func()) Measurement {
+	// runtime/metrics is cheaper to read but only counts small allocations as
+	// their spans are refilled, ReadMemStats is exact
+	var stats runtime.MemStats
+
+	runtime.ReadMemStats(&stats)
+	before := stats.Mallocs
+
+	start := time.Now()
+	fn()
+	elapsed := time.Since(start)
+
+	runtime.ReadMemStats(&stats)
+	after := stats.Mallocs
+
+	return Measurement{Duration: elapsed, Allocs: after - before}
+}
+
+// WriteTable writes the results as a human readable table to w, with a row per
+// part and a final total.
+//
+// The parse cost of each day is shown on its part 1 row.
+func WriteTable(w io.Writer, results Results) error {
+	tab := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tab, "DAY\tPART\tANSWER\tPARSE\tSOLVE\tALLOCS")
+
+	var parse, solve time.Duration
+	var allocs uint64
+
+	for _, result := range results.Days {
+		parse += result.Parse.Duration
+		allocs += result.Parse.Allocs
+
+		if result.Err != nil {
+			fmt.Fprintf(tab, "%d\t-\terror: %s\t%s\t-\t%d\n", result.Day, summary(result.Err), result.Parse.Duration, result.Parse.Allocs)
+			continue
+		}
+
+		for i, part := range result.Parts {
+			solve += part.Duration
+			allocs += part.Allocs
+
+			answer := part.Answer.String()
+			if part.Err != nil {
+				answer = "error: " + summary(part.Err)
+			}
+
+			parseTime := ""
+			rowAllocs := part.Allocs
+			if i == 0 {
+				parseTime = result.Parse.Duration.String()
+				rowAllocs += result.Parse.Allocs
+			}
+
+			fmt.Fprintf(tab, "%d\t%d\t%s\t%s\t%s\t%d\n", result.Day, i+1, answer, parseTime, part.Duration, rowAllocs)
+		}
+	}
+
+	fmt.Fprintf(tab, "TOTAL\t\t\t%s\t%s\t%d\n", parse, solve, allocs)
+
+	if err := tab.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\nFinished in %s\n", results.Elapsed)
+	return err
+}
+
+// summary returns the first line of err, so that errors with multi-line detail
+// don't break up the table. The full error is still returned by [Results.Err].
+func summary(err error) string {
+	first, _, _ := strings.Cut(err.Error(), "\n")
+	return first
+}
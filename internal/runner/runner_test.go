@@ -0,0 +1,152 @@
+package runner
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/test"
+)
+
+// fake is an [aoc.Solution] whose answers are derived from its input.
+type fake struct {
+	input string
+}
+
+func (f *fake) Parse(input string) error {
+	if input == "bad" {
+		return errors.New("bad input\nwith some detail")
+	}
+	f.input = input
+	return nil
+}
+
+func (f *fake) Part1() (aoc.Answer, error) {
+	return aoc.Answer(f.input + "-1"), nil
+}
+
+func (f *fake) Part2() (aoc.Answer, error) {
+	switch f.input {
+	case "unsolved":
+		return "", errors.New("not done yet")
+	case "scaffolded":
+		return "", fmt.Errorf("part 2: %w", aoc.ErrUnsolved)
+	}
+	return aoc.Answer(f.input + "-2"), nil
+}
+
+func fakeDay(number int, input string) aoc.Day {
+	return aoc.Day{
+		Number: number,
+		Input:  input,
+		New:    func() aoc.Solution { return &fake{} },
+	}
+}
+
+func TestRun(t *testing.T) {
+	var days []aoc.Day
+	for i := 1; i <= 25; i++ {
+		days = append(days, fakeDay(i, strings.Repeat("x", i)))
+	}
+
+	for _, workers := range []int{0, 1, 4, 100} {
+		results := Run(days, workers)
+		test.Ok(t, results.Err())
+		test.Equal(t, len(results.Days), 25)
+
+		for i, result := range results.Days {
+			want := strings.Repeat("x", i+1)
+			test.Equal(t, result.Day, i+1)                               // Results out of order
+			test.Equal(t, result.Parts[0].Answer, aoc.Answer(want+"-1")) // Wrong part 1 answer
+			test.Equal(t, result.Parts[1].Answer, aoc.Answer(want+"-2")) // Wrong part 2 answer
+		}
+	}
+}
+
+// streamer is a fake that can also stream its input.
+type streamer struct{ fake }
+
+func (s *streamer) Stream(r io.Reader) ([2]aoc.Answer, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return [2]aoc.Answer{}, err
+	}
+	return [2]aoc.Answer{aoc.Answer(string(raw) + "-1"), aoc.Answer(string(raw) + "-2")}, nil
+}
+
+func TestRunStream(t *testing.T) {
+	open := func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("streamed")), nil
+	}
+
+	t.Run("streamer", func(t *testing.T) {
+		day := aoc.Day{Number: 1, Open: open, New: func() aoc.Solution { return &streamer{} }}
+		results := Run([]aoc.Day{day}, 1)
+		test.Ok(t, results.Err())
+		test.Equal(t, results.Days[0].Parts[0].Answer, aoc.Answer("streamed-1"))
+		test.Equal(t, results.Days[0].Parts[1].Answer, aoc.Answer("streamed-2"))
+	})
+
+	t.Run("not a streamer", func(t *testing.T) {
+		day := aoc.Day{Number: 1, Open: open, New: func() aoc.Solution { return &fake{} }}
+		test.Err(t, Run([]aoc.Day{day}, 1).Err())
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		failing := func() (io.ReadCloser, error) {
+			return io.NopCloser(iotest.ErrReader(iotest.ErrTimeout)), nil
+		}
+		day := aoc.Day{Number: 1, Open: failing, New: func() aoc.Solution { return &streamer{} }}
+		test.Err(t, Run([]aoc.Day{day}, 1).Err())
+	})
+}
+
+func TestRunUnsolved(t *testing.T) {
+	results := Run([]aoc.Day{fakeDay(1, "fine"), fakeDay(2, "scaffolded")}, 1)
+	test.Ok(t, results.Err()) // A part that isn't solved yet isn't a failure
+	test.True(t, errors.Is(results.Days[1].Parts[1].Err, aoc.ErrUnsolved))
+}
+
+func TestRunErrors(t *testing.T) {
+	days := []aoc.Day{
+		fakeDay(1, "fine"),
+		fakeDay(2, "bad"),
+		fakeDay(3, "unsolved"),
+	}
+
+	results := Run(days, 2)
+	err := results.Err()
+	test.Err(t, err)
+
+	test.Ok(t, results.Days[0].Err)
+	test.Err(t, results.Days[1].Err)
+	test.Ok(t, results.Days[2].Err)
+	test.Ok(t, results.Days[2].Parts[0].Err)
+	test.Err(t, results.Days[2].Parts[1].Err)
+
+	test.True(t, strings.Contains(err.Error(), "day 2: bad input\nwith some detail"))
+	test.True(t, strings.Contains(err.Error(), "day 3 part 2: not done yet"))
+}
+
+func TestWriteTable(t *testing.T) {
+	results := Run([]aoc.Day{fakeDay(1, "one"), fakeDay(2, "bad")}, 1)
+
+	buf := &bytes.Buffer{}
+	test.Ok(t, WriteTable(buf, results))
+
+	got := buf.String()
+	for _, want := range []string{"DAY", "one-1", "one-2", "error: bad input", "TOTAL", "Finished in"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("table missing %q:\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(got, "with some detail") {
+		t.Errorf("table should only show the first line of errors:\n%s", got)
+	}
+}
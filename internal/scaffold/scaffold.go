@@ -0,0 +1,240 @@
+// Package scaffold generates the boilerplate for a new day and registers it with
+// the runner.
+package scaffold
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+)
+
+// Template names, any of these may be overridden by a file of the same name in
+// the custom templates directory.
+const (
+	dayTemplate      = "day.go.tmpl"
+	dayTestTemplate  = "day_test.go.tmpl"
+	registryTemplate = "registry.go.tmpl"
+)
+
+// filePerms is the permission bits for generated files.
+const filePerms = 0o644
+
+// dayPackage matches the name of a day's package directory e.g. day01.
+var dayPackage = regexp.MustCompile(`^day\d{2}$`)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// data is the data passed to every template.
+type data struct {
+	Module   string   // The Go module path e.g. github.com/FollowTheProcess/aoc2024
+	Package  string   // The new day's package name e.g. day04
+	Packages []string // Every day package that should be registered, in order
+	Day      int      // The new day number e.g. 4
+	Year     int      // The Advent of Code event year
+}
+
+// Generator creates new days in a repository.
+type Generator struct {
+	root      string // The repository root, containing go.mod
+	templates string // Optional directory of custom templates, "" to use the defaults
+}
+
+// New returns a Generator for the repository at root. If templates is non-empty,
+// templates in that directory take precedence over the built in ones.
+func New(root, templates string) Generator {
+	return Generator{root: root, templates: templates}
+}
+
+// Generate creates the package for the given day, containing the solution, test and
+// empty input and answers files, then regenerates the day registry so the runner
+// picks it up.
+//
+// Generate refuses to touch a day that already exists. Every template is rendered
+// before anything is created, and if writing fails part way the new day's directory
+// is removed, so a failed Generate can simply be run again.
+func (g Generator) Generate(day int) error {
+	if day < 1 || day > 25 {
+		return fmt.Errorf("invalid day %d, must be between 1 and 25", day)
+	}
+
+	module, err := g.module()
+	if err != nil {
+		return err
+	}
+
+	pkg := fmt.Sprintf("day%02d", day)
+	dir := filepath.Join(g.root, "internal", pkg)
+
+	d := data{Module: module, Package: pkg, Day: day, Year: aoc.Year}
+
+	files := []struct {
+		name     string // Name of the file to create
+		template string // Template to render, "" for an empty file
+		contents []byte // The rendered contents
+	}{
+		{name: pkg + ".go", template: dayTemplate},
+		{name: pkg + "_test.go", template: dayTestTemplate},
+		{name: pkg + ".txt"},
+		{name: "answers.txt"},
+	}
+
+	for i, file := range files {
+		if file.template == "" {
+			continue
+		}
+
+		files[i].contents, err = g.render(file.template, d)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Mkdir (not MkdirAll) fails if the directory exists, which is exactly
+	// what stops us clobbering someone's work
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("%s already exists, refusing to overwrite it", dir)
+		}
+		return err
+	}
+
+	for _, file := range files {
+		if err := writeNew(filepath.Join(dir, file.name), file.contents); err != nil {
+			return errors.Join(err, os.RemoveAll(dir))
+		}
+	}
+
+	if err := g.Register(); err != nil {
+		return errors.Join(err, os.RemoveAll(dir))
+	}
+
+	return nil
+}
+
+// Register regenerates the day registry from the day packages present on disk.
+func (g Generator) Register() error {
+	module, err := g.module()
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(g.root, "internal"))
+	if err != nil {
+		return err
+	}
+
+	var packages []string
+	for _, entry := range entries {
+		if entry.IsDir() && dayPackage.MatchString(entry.Name()) {
+			packages = append(packages, entry.Name())
+		}
+	}
+
+	// ReadDir sorts by name already, but zero padding is what makes that the
+	// same as day order so be explicit
+	slices.Sort(packages)
+
+	contents, err := g.render(registryTemplate, data{Module: module, Packages: packages, Year: aoc.Year})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(g.root, "internal", "days", "registry.go"), contents, filePerms)
+}
+
+// render executes the named template with d, formatting the result as Go source
+// if it is a Go template.
+func (g Generator) render(name string, d data) ([]byte, error) {
+	raw, err := g.template(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse template %s: %w", name, err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, d); err != nil {
+		return nil, fmt.Errorf("could not execute template %s: %w", name, err)
+	}
+
+	if !strings.HasSuffix(name, ".go.tmpl") {
+		return buf.Bytes(), nil
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("template %s produced invalid Go: %w", name, err)
+	}
+
+	return formatted, nil
+}
+
+// template returns the raw contents of the named template, preferring a custom
+// one if present.
+func (g Generator) template(name string) ([]byte, error) {
+	if g.templates != "" {
+		raw, err := os.ReadFile(filepath.Join(g.templates, name))
+		if err == nil {
+			return raw, nil
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	return defaultTemplates.ReadFile("templates/" + name)
+}
+
+// module returns the module path declared in the repository's go.mod.
+func (g Generator) module() (string, error) {
+	file, err := os.Open(filepath.Join(g.root, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("could not find go.mod, is %s the repository root?: %w", g.root, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.TrimSpace(module), nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", errors.New("go.mod has no module directive")
+}
+
+// writeNew writes contents to a new file at path, failing if it already exists.
+func writeNew(path string, contents []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, filePerms)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(contents); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,139 @@
+package scaffold
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/FollowTheProcess/test"
+)
+
+// newRepo creates a minimal repository layout in a temporary directory with
+// day 1 already present, returning its root.
+func newRepo(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+
+	test.Ok(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/aoc\n\ngo 1.23\n"), filePerms))
+	test.Ok(t, os.MkdirAll(filepath.Join(root, "internal", "days"), 0o755))
+	test.Ok(t, os.MkdirAll(filepath.Join(root, "internal", "day01"), 0o755))
+
+	return root
+}
+
+func TestGenerate(t *testing.T) {
+	root := newRepo(t)
+
+	test.Ok(t, New(root, "").Generate(4))
+
+	dir := filepath.Join(root, "internal", "day04")
+	for _, name := range []string{"day04.go", "day04_test.go"} {
+		_, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.AllErrors)
+		test.Ok(t, err)
+	}
+
+	for _, name := range []string{"day04.txt", "answers.txt"} {
+		contents, err := os.ReadFile(filepath.Join(dir, name))
+		test.Ok(t, err)
+		test.Equal(t, len(contents), 0) // Input and answers should start empty
+	}
+
+	source, err := os.ReadFile(filepath.Join(dir, "day04.go"))
+	test.Ok(t, err)
+	test.True(t, strings.Contains(string(source), "package day04"))
+	test.True(t, strings.Contains(string(source), "//go:embed day04.txt"))
+	test.True(t, strings.Contains(string(source), "Number:  4,"))
+	test.True(t, strings.Contains(string(source), `"example.com/aoc/internal/aoc"`))
+
+	registry, err := os.ReadFile(filepath.Join(root, "internal", "days", "registry.go"))
+	test.Ok(t, err)
+	test.True(t, strings.Contains(string(registry), "day01.Day(),\n\t\tday04.Day(),"))
+	test.True(t, strings.Contains(string(registry), `"example.com/aoc/internal/day04"`))
+}
+
+func TestGenerateRefusesToOverwrite(t *testing.T) {
+	root := newRepo(t)
+
+	err := New(root, "").Generate(1)
+	test.Err(t, err)
+	test.True(t, strings.Contains(err.Error(), "refusing to overwrite"))
+
+	// Nothing should have been written into the existing day
+	entries, err := os.ReadDir(filepath.Join(root, "internal", "day01"))
+	test.Ok(t, err)
+	test.Equal(t, len(entries), 0)
+}
+
+func TestGenerateInvalidDay(t *testing.T) {
+	root := newRepo(t)
+
+	for _, day := range []int{-1, 0, 26} {
+		test.Err(t, New(root, "").Generate(day))
+	}
+}
+
+func TestGenerateNoGoMod(t *testing.T) {
+	test.Err(t, New(t.TempDir(), "").Generate(4))
+}
+
+func TestGenerateCustomTemplate(t *testing.T) {
+	root := newRepo(t)
+	templates := t.TempDir()
+
+	custom := "package {{ .Package }}\n\n// Custom template for day {{ .Day }} of {{ .Year }}\n"
+	test.Ok(t, os.WriteFile(filepath.Join(templates, dayTemplate), []byte(custom), filePerms))
+
+	test.Ok(t, New(root, templates).Generate(12))
+
+	source, err := os.ReadFile(filepath.Join(root, "internal", "day12", "day12.go"))
+	test.Ok(t, err)
+	test.Equal(t, string(source), "package day12\n\n// Custom template for day 12 of 2024\n")
+
+	// The test template wasn't overridden so should be the default
+	testSource, err := os.ReadFile(filepath.Join(root, "internal", "day12", "day12_test.go"))
+	test.Ok(t, err)
+	test.True(t, strings.Contains(string(testSource), "func TestSolution"))
+}
+
+func TestGenerateBadTemplate(t *testing.T) {
+	root := newRepo(t)
+	templates := t.TempDir()
+
+	test.Ok(t, os.WriteFile(filepath.Join(templates, dayTemplate), []byte("package {{ .Nope }}"), filePerms))
+
+	test.Err(t, New(root, templates).Generate(5))
+
+	// Nothing should be left behind to stop a second attempt
+	_, err := os.Stat(filepath.Join(root, "internal", "day05"))
+	test.True(t, errors.Is(err, fs.ErrNotExist))
+	test.Ok(t, New(root, "").Generate(5))
+}
+
+func TestGenerateRegisterFails(t *testing.T) {
+	root := newRepo(t)
+
+	// No internal/days directory to write the registry into
+	test.Ok(t, os.Remove(filepath.Join(root, "internal", "days")))
+
+	test.Err(t, New(root, "").Generate(6))
+
+	_, err := os.Stat(filepath.Join(root, "internal", "day06"))
+	test.True(t, errors.Is(err, fs.ErrNotExist))
+}
+
+func TestGenerateUnsolved(t *testing.T) {
+	root := newRepo(t)
+	test.Ok(t, New(root, "").Generate(7))
+
+	source, err := os.ReadFile(filepath.Join(root, "internal", "day07", "day07.go"))
+	test.Ok(t, err)
+
+	// A new day's parts must report aoc.ErrUnsolved so they count as missing, not failed
+	test.True(t, strings.Contains(string(source), `fmt.Errorf("part 1: %w", aoc.ErrUnsolved)`))
+	test.True(t, strings.Contains(string(source), `fmt.Errorf("part 2: %w", aoc.ErrUnsolved)`))
+}
@@ -0,0 +1,156 @@
+// Package verify checks the answers each day produces against the known answers
+// checked in alongside its input, so refactors can't silently change them.
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/aoc2024/internal/runner"
+)
+
+// Status is the outcome of checking a single part.
+type Status int
+
+const (
+	// Pass means the answer matched the known answer.
+	Pass Status = iota
+
+	// Fail means the answer did not match, or the part could not be solved.
+	Fail
+
+	// Missing means there is no known answer to check against, or the part isn't
+	// solved yet.
+	Missing
+)
+
+// String implements [fmt.Stringer] for a Status.
+func (s Status) String() string {
+	switch s {
+	case Pass:
+		return "pass"
+	case Fail:
+		return "fail"
+	case Missing:
+		return "missing"
+	default:
+		return fmt.Sprintf("Status(%d)", int(s))
+	}
+}
+
+// Check is the result of verifying one part of a day.
+type Check struct {
+	Err    error      // Why the part failed if it could not be solved, or its answers could not be read
+	Got    aoc.Answer // The answer the solution produced
+	Want   aoc.Answer // The known answer, empty if Missing
+	Day    int        // The day number
+	Part   int        // The part, 1 or 2
+	Status Status     // The outcome of the check
+}
+
+// Report is the result of verifying a set of days.
+type Report struct {
+	Checks []Check // One check per part, in day then part order
+}
+
+// Count returns the number of checks with the given status.
+func (r Report) Count(status Status) int {
+	count := 0
+	for _, check := range r.Checks {
+		if check.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Err returns an error if any check failed, or nil if every known answer matched.
+func (r Report) Err() error {
+	if failed := r.Count(Fail); failed != 0 {
+		return fmt.Errorf("%d of %d parts failed verification", failed, len(r.Checks))
+	}
+
+	return nil
+}
+
+// Verify solves every day using at most workers goroutines at once and checks the
+// answers against each day's known answers.
+func Verify(days []aoc.Day, workers int) Report {
+	results := runner.Run(days, workers)
+
+	var report Report
+	for index, result := range results.Days {
+		known, knownErr := aoc.ParseAnswers(days[index].Answers)
+
+		for i, part := range result.Parts {
+			check := Check{
+				Day:  result.Day,
+				Part: i + 1,
+				Got:  part.Answer,
+				Want: known[i],
+			}
+
+			switch {
+			case knownErr != nil:
+				check.Status = Fail
+				check.Err = fmt.Errorf("bad answers file: %w", knownErr)
+			case result.Err != nil:
+				check.Status = Fail
+				check.Err = result.Err
+			case errors.Is(part.Err, aoc.ErrUnsolved):
+				check.Status = Missing
+				check.Err = part.Err
+			case part.Err != nil:
+				check.Status = Fail
+				check.Err = part.Err
+			case check.Want == "":
+				check.Status = Missing
+			case check.Got != check.Want:
+				check.Status = Fail
+				check.Err = errors.New("answer changed")
+			default:
+				check.Status = Pass
+			}
+
+			report.Checks = append(report.Checks, check)
+		}
+	}
+
+	return report
+}
+
+// WriteReport writes a human readable table of the report to w, finishing with
+// the number of parts that passed, failed and were missing a known answer.
+func WriteReport(w io.Writer, report Report) error {
+	tab := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tab, "DAY\tPART\tSTATUS\tGOT\tWANT\tDETAIL")
+
+	for _, check := range report.Checks {
+		detail := ""
+		if check.Err != nil {
+			// Only the first line, multi-line errors would break up the table
+			detail, _, _ = strings.Cut(check.Err.Error(), "\n")
+		}
+
+		fmt.Fprintf(tab, "%d\t%d\t%s\t%s\t%s\t%s\n", check.Day, check.Part, check.Status, check.Got, check.Want, detail)
+	}
+
+	if err := tab.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(
+		w,
+		"\n%d passed, %d failed, %d missing\n",
+		report.Count(Pass),
+		report.Count(Fail),
+		report.Count(Missing),
+	)
+
+	return err
+}
@@ -0,0 +1,104 @@
+package verify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/FollowTheProcess/aoc2024/internal/aoc"
+	"github.com/FollowTheProcess/test"
+)
+
+// echo is an [aoc.Solution] that answers both parts with its input.
+type echo struct {
+	input string
+}
+
+func (e *echo) Parse(input string) error {
+	if input == "bad" {
+		return errors.New("bad input")
+	}
+	e.input = input
+	return nil
+}
+
+func (e *echo) Part1() (aoc.Answer, error) {
+	return aoc.Answer(e.input), nil
+}
+
+func (e *echo) Part2() (aoc.Answer, error) {
+	if e.input == "unsolved" {
+		return "", fmt.Errorf("part 2: %w", aoc.ErrUnsolved)
+	}
+	return aoc.Answer(e.input), nil
+}
+
+func echoDay(number int, input, answers string) aoc.Day {
+	return aoc.Day{
+		Number:  number,
+		Input:   input,
+		Answers: answers,
+		New:     func() aoc.Solution { return &echo{} },
+	}
+}
+
+func TestVerify(t *testing.T) {
+	days := []aoc.Day{
+		echoDay(1, "42", "part1: 42\npart2: 42\n"),
+		echoDay(2, "42", "part1: 42\npart2: 24\n"),
+		echoDay(3, "42", "part1: 42\n"),
+		echoDay(4, "bad", "part1: 42\npart2: 42\n"),
+		echoDay(5, "42", "nonsense"),
+		echoDay(6, "unsolved", ""),
+	}
+
+	report := Verify(days, 2)
+
+	got := make([]Status, 0, len(report.Checks))
+	for _, check := range report.Checks {
+		got = append(got, check.Status)
+	}
+
+	want := []Status{
+		Pass, Pass, // Both match
+		Pass, Fail, // Part 2 changed
+		Pass, Missing, // No part 2 answer yet
+		Fail, Fail, // Input doesn't parse
+		Fail, Fail, // Answers file doesn't parse
+		Missing, Missing, // Freshly scaffolded
+	}
+
+	test.Diff(t, got, want)
+	test.Equal(t, report.Count(Pass), 4)
+	test.Equal(t, report.Count(Fail), 5)
+	test.Equal(t, report.Count(Missing), 3)
+	test.Err(t, report.Err())
+}
+
+func TestVerifyUnsolved(t *testing.T) {
+	report := Verify([]aoc.Day{echoDay(1, "unsolved", "part1: unsolved\n")}, 1)
+	test.Ok(t, report.Err()) // A part that isn't solved yet shouldn't fail verification
+	test.Equal(t, report.Count(Pass), 1)
+	test.Equal(t, report.Count(Missing), 1)
+}
+
+func TestVerifyAllPass(t *testing.T) {
+	report := Verify([]aoc.Day{echoDay(1, "1", "part1: 1\npart2: 1\n")}, 0)
+	test.Ok(t, report.Err())
+}
+
+func TestWriteReport(t *testing.T) {
+	report := Verify([]aoc.Day{echoDay(1, "42", "part1: 42\npart2: 24\n")}, 1)
+
+	buf := &bytes.Buffer{}
+	test.Ok(t, WriteReport(buf, report))
+
+	got := buf.String()
+	for _, want := range []string{"STATUS", "pass", "fail", "answer changed", "1 passed, 1 failed, 0 missing"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report missing %q:\n%s", want, got)
+		}
+	}
+}
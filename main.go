@@ -1,9 +1,16 @@
+// Command aoc runs the Advent of Code 2024 solutions.
 package main
 
 import (
-	"fmt"
+	"os"
+
+	"github.com/FollowTheProcess/aoc2024/internal/cli"
+	"github.com/FollowTheProcess/msg"
 )
 
 func main() {
-	fmt.Println("A little copying is better than a little dependency.")
+	if err := cli.New(os.Stdin, os.Stdout, os.Stderr).Run(os.Args[1:]); err != nil {
+		msg.Error("%v", err)
+		os.Exit(1)
+	}
 }